@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileYAML = ".i18nator.yaml"
+	configFileJSON = ".i18nator.json"
+
+	defaultLocalesDir       = "pkg/translates/localize/i18n"
+	defaultFileNameTemplate = "{{.Code}}.json"
+	defaultProvider         = "google-free"
+	defaultFormat           = "json"
+	defaultIndent           = "2"
+)
+
+// LanguageConfig describes one translation target: the locale code sent to
+// the provider, an optional human-readable name, and an optional file name
+// override for projects that don't want the generated "{code}.json" name.
+type LanguageConfig struct {
+	Code        string `json:"code" yaml:"code"`
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	File        string `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// Config drives i18nator's behavior and is loaded from .i18nator.yaml or
+// .i18nator.json in the current directory, similar to AdGuardHome's
+// .twosky.json. Projects without either file fall back to defaultConfig.
+type Config struct {
+	SourceLanguage   string                       `json:"sourceLanguage" yaml:"sourceLanguage"`
+	TargetLanguages  []LanguageConfig             `json:"targetLanguages" yaml:"targetLanguages"`
+	LocalesDir       string                       `json:"localesDir,omitempty" yaml:"localesDir,omitempty"`
+	FileNameTemplate string                       `json:"fileNameTemplate,omitempty" yaml:"fileNameTemplate,omitempty"`
+	Provider         string                       `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Overrides        map[string]map[string]string `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Remote           RemoteConfig                 `json:"remote,omitempty" yaml:"remote,omitempty"`
+	Format           string                       `json:"format,omitempty" yaml:"format,omitempty"`
+	Indent           string                       `json:"indent,omitempty" yaml:"indent,omitempty"`
+	FlatKeys         bool                         `json:"flatKeys,omitempty" yaml:"flatKeys,omitempty"`
+}
+
+// RemoteConfig configures the pull/push sync backend: which TMS to talk to
+// and which project/branch within it. Auth tokens are never stored here —
+// they're read from backend-specific environment variables.
+type RemoteConfig struct {
+	Backend   string `json:"backend,omitempty" yaml:"backend,omitempty"`
+	ProjectID string `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	// Branch is stored on the Crowdin backend, but no backend includes it in
+	// its requests, so push/pull are not scoped to a branch.
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	// Component is required by backends (Weblate) whose API addresses a
+	// translation component within a project, rather than the project alone.
+	Component string `json:"component,omitempty" yaml:"component,omitempty"`
+	// Experimental must be set to true to use push/pull at all. None of the
+	// remote backends have been exercised against a live TMS project (see
+	// RemoteBackend's doc comment), so this is an explicit acknowledgment
+	// that push/pull are unsupported/best-effort rather than something
+	// wired into the command tree by default.
+	Experimental bool `json:"experimental,omitempty" yaml:"experimental,omitempty"`
+}
+
+// defaultConfig reproduces the hard-coded en/km/zh-CN setup i18nator used
+// before config files existed, so projects without a .i18nator.yaml/.json
+// keep working unchanged.
+func defaultConfig() *Config {
+	return &Config{
+		SourceLanguage: "en",
+		TargetLanguages: []LanguageConfig{
+			{Code: "en", File: "en.json"},
+			{Code: "km", File: "km.json"},
+			{Code: "zh-CN", File: "zh.json"},
+		},
+		LocalesDir: defaultLocalesDir,
+		Provider:   defaultProvider,
+		Format:     defaultFormat,
+		Indent:     defaultIndent,
+	}
+}
+
+// loadConfig reads .i18nator.yaml or .i18nator.json from the current
+// directory (YAML takes precedence when both exist), falling back to
+// defaultConfig when neither is present.
+func loadConfig() (*Config, error) {
+	for _, name := range []string{configFileYAML, configFileJSON} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %v", name, err)
+		}
+
+		cfg := &Config{}
+		if strings.HasSuffix(name, ".yaml") {
+			err = yaml.Unmarshal(data, cfg)
+		} else {
+			err = json.Unmarshal(data, cfg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", name, err)
+		}
+
+		cfg.applyDefaults()
+		return cfg, nil
+	}
+
+	return defaultConfig(), nil
+}
+
+// applyDefaults fills in anything the user's config file left blank.
+func (c *Config) applyDefaults() {
+	if c.SourceLanguage == "" {
+		c.SourceLanguage = "en"
+	}
+	if c.LocalesDir == "" {
+		c.LocalesDir = defaultLocalesDir
+	}
+	if c.Provider == "" {
+		c.Provider = defaultProvider
+	}
+	if c.FileNameTemplate == "" {
+		c.FileNameTemplate = defaultFileNameTemplate
+	}
+	if c.Format == "" {
+		c.Format = defaultFormat
+	}
+	if c.Indent == "" {
+		c.Indent = defaultIndent
+	}
+}
+
+// fileName renders FileNameTemplate for a locale code.
+func (c *Config) fileName(code string) string {
+	return strings.ReplaceAll(c.FileNameTemplate, "{{.Code}}", code)
+}
+
+// LocaleFile returns the file name for a target language, honoring an
+// explicit File override or falling back to FileNameTemplate.
+func (c *Config) LocaleFile(lang LanguageConfig) string {
+	if lang.File != "" {
+		return lang.File
+	}
+	return c.fileName(lang.Code)
+}
+
+// SourceFile returns the file name holding the source-language strings.
+func (c *Config) SourceFile() string {
+	for _, lang := range c.TargetLanguages {
+		if lang.Code == c.SourceLanguage {
+			return c.LocaleFile(lang)
+		}
+	}
+	return c.fileName(c.SourceLanguage)
+}
+
+// LocalesPath resolves LocalesDir relative to the current working directory.
+func (c *Config) LocalesPath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %v", err)
+	}
+	return filepath.Join(cwd, c.LocalesDir), nil
+}
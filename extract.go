@@ -0,0 +1,283 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// extractFuncs lists the translation call forms extract recognizes: bare
+// T/Tf/Tn helpers and the i18n.T/Tf/Tn package-qualified equivalents.
+var extractFuncs = map[string]bool{
+	"T":       true,
+	"Tf":      true,
+	"Tn":      true,
+	"i18n.T":  true,
+	"i18n.Tf": true,
+	"i18n.Tn": true,
+}
+
+// templateCallRe matches {{ T "..." }} calls in .tmpl/.html templates.
+var templateCallRe = regexp.MustCompile(`\{\{\s*T\s+"((?:[^"\\]|\\.)*)"`)
+
+func newExtractCmd() *cobra.Command {
+	var prune bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "extract [dir]",
+		Short: "Scan source for translation calls and sync keys",
+		Long:  "Walk dir (default \".\"), collect string literals passed to T/Tf/Tn-style calls in .go files and {{ T \"...\" }} calls in .tmpl/.html files, and sync them into the source locale file",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			runExtract(dir, prune, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&prune, "prune", false, "remove keys no longer referenced anywhere")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report adds/removes without touching files")
+
+	return cmd
+}
+
+// runExtract scans dir for translation calls and adds missing keys to (or,
+// with prune, removes stale keys from) every locale file.
+func runExtract(dir string, prune, dryRun bool) {
+	if err := initEnv(); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	found, err := scanForKeys(dir)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+
+	var added, removed []string
+	for key := range found {
+		if _, exists := sourceData[key]; !exists {
+			added = append(added, key)
+		}
+	}
+	if prune {
+		for key := range sourceData {
+			if !found[key] {
+				removed = append(removed, key)
+			}
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Println("✨ No changes needed, locales are already in sync")
+		return
+	}
+
+	for _, key := range added {
+		fmt.Printf("➕ %s\n", key)
+	}
+	for _, key := range removed {
+		fmt.Printf("➖ %s\n", key)
+	}
+
+	if dryRun {
+		fmt.Println("\n🔍 Dry run: no files were changed")
+		return
+	}
+
+	fmt.Println()
+	if len(added) > 0 {
+		forEachLanguage(cfg.TargetLanguages, defaultConcurrency, func(lang LanguageConfig) {
+			addKeysToLanguage(added, lang)
+		})
+		saveTranslatorCache()
+	}
+
+	if prune {
+		for _, key := range removed {
+			for _, lang := range cfg.TargetLanguages {
+				file := cfg.LocaleFile(lang)
+				path := filepath.Join(basePath, file)
+				data := loadLocaleFile(path)
+				delete(data, key)
+				if err := saveLocaleFile(path, data); err != nil {
+					fmt.Printf("❌ %s: failed to save: %v\n", file, err)
+					continue
+				}
+				fmt.Printf("✅ %s: removed '%s'\n", file, key)
+			}
+		}
+	}
+
+	fmt.Printf("\n✨ extract: %d added, %d removed\n", len(added), len(removed))
+}
+
+// addKeysToLanguage adds every key in keys — seeded with the key string
+// itself as source text, extract's only source of English copy — to lang's
+// locale file. Plain keys (no ICU plural/placeholder syntax, no override) go
+// through the provider's batch API in one request instead of one HTTP call
+// per key; everything else falls back to translateMessage's segment-aware
+// handling, mirroring processAddOrUpdate's per-key logic.
+func addKeysToLanguage(keys []string, lang LanguageConfig) {
+	path := filepath.Join(basePath, cfg.LocaleFile(lang))
+	file := filepath.Base(path)
+	data := loadLocaleFile(path)
+
+	if lang.Code == cfg.SourceLanguage {
+		for _, key := range keys {
+			data[key] = Message{Text: key}
+		}
+	} else {
+		var plainKeys, structuredKeys []string
+		for _, key := range keys {
+			if _, overridden := cfg.Overrides[lang.Code][key]; overridden {
+				structuredKeys = append(structuredKeys, key)
+			} else if icuPluralRe.MatchString(key) || placeholderRe.MatchString(key) {
+				structuredKeys = append(structuredKeys, key)
+			} else {
+				plainKeys = append(plainKeys, key)
+			}
+		}
+
+		if batcher, ok := provider.(BatchTranslationProvider); ok && len(plainKeys) > 0 {
+			translated, err := batcher.TranslateBatch(plainKeys, lang.Code)
+			if err != nil {
+				fmt.Printf("❌ %s: batch translation failed (%v), using source text\n", file, err)
+				structuredKeys = append(structuredKeys, plainKeys...)
+				plainKeys = nil
+			} else {
+				for i, key := range plainKeys {
+					data[key] = Message{Text: translated[i]}
+				}
+			}
+		} else {
+			structuredKeys = append(structuredKeys, plainKeys...)
+			plainKeys = nil
+		}
+
+		for _, key := range structuredKeys {
+			source := Message{Text: key}
+			translated := source
+			if override, ok := cfg.Overrides[lang.Code][key]; ok {
+				translated = Message{Text: override}
+			} else if t, err := translateMessage(source, provider, lang.Code); err != nil {
+				fmt.Printf("❌ %s: Translation failed (%v), using source text\n", file, err)
+			} else if err := validateMessage(key, source, t); err != nil {
+				fmt.Printf("❌ %s: %v, using source text\n", file, err)
+			} else {
+				translated = t
+			}
+			data[key] = translated
+		}
+	}
+
+	for _, key := range keys {
+		fmt.Printf("✅ %s: %s\n", file, data[key])
+	}
+
+	if err := saveLocaleFile(path, data); err != nil {
+		fmt.Printf("❌ %s: failed to save: %v\n", file, err)
+	}
+}
+
+// scanForKeys walks dir, parsing .go files for T/Tf/Tn-style calls and
+// .tmpl/.html files for {{ T "..." }} template calls.
+func scanForKeys(dir string) (map[string]bool, error) {
+	keys := map[string]bool{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(path, ".go"):
+			if err := scanGoFile(path, keys); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+		case strings.HasSuffix(path, ".tmpl"), strings.HasSuffix(path, ".html"):
+			if err := scanTemplateFile(path, keys); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+		}
+		return nil
+	})
+
+	return keys, err
+}
+
+// scanGoFile parses a single .go file and records the first string-literal
+// argument of every recognized translation call.
+func scanGoFile(path string, keys map[string]bool) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || !isTranslationCall(call.Fun) {
+			return true
+		}
+
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if value, err := strconv.Unquote(lit.Value); err == nil {
+				keys[value] = true
+			}
+		}
+
+		return true
+	})
+
+	return nil
+}
+
+// isTranslationCall reports whether fun is a bare T/Tf/Tn identifier or an
+// i18n.T/Tf/Tn selector listed in extractFuncs.
+func isTranslationCall(fun ast.Expr) bool {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return extractFuncs[f.Name]
+	case *ast.SelectorExpr:
+		if ident, ok := f.X.(*ast.Ident); ok {
+			return extractFuncs[ident.Name+"."+f.Sel.Name]
+		}
+	}
+	return false
+}
+
+// scanTemplateFile records every {{ T "..." }} key in a .tmpl/.html file.
+func scanTemplateFile(path string, keys map[string]bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	for _, match := range templateCallRe.FindAllStringSubmatch(string(data), -1) {
+		keys[match[1]] = true
+	}
+
+	return nil
+}
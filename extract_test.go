@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanGoFile(t *testing.T) {
+	src := `package example
+
+import "example.com/i18n"
+
+func handler() {
+	T("Welcome back")
+	Tf("Hello %s", name)
+	Tn("# item", "# items", n)
+	i18n.T("Log in")
+	other.Call("not a translation")
+}
+`
+	path := filepath.Join(t.TempDir(), "handler.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	keys := map[string]bool{}
+	if err := scanGoFile(path, keys); err != nil {
+		t.Fatalf("scanGoFile: %v", err)
+	}
+
+	want := map[string]bool{
+		"Welcome back": true,
+		"Hello %s":     true,
+		"# item":       true,
+		"Log in":       true,
+	}
+	for key := range want {
+		if !keys[key] {
+			t.Errorf("scanGoFile did not find key %q in %#v", key, keys)
+		}
+	}
+	if keys["not a translation"] {
+		t.Error("scanGoFile recorded a string literal from an unrecognized call")
+	}
+}
+
+func TestScanTemplateFile(t *testing.T) {
+	src := `<h1>{{ T "Page title" }}</h1>
+<p>{{ T "Welcome, \"friend\"" }}</p>
+<p>{{ .NotATranslation }}</p>
+`
+	path := filepath.Join(t.TempDir(), "page.html")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	keys := map[string]bool{}
+	if err := scanTemplateFile(path, keys); err != nil {
+		t.Fatalf("scanTemplateFile: %v", err)
+	}
+
+	want := map[string]bool{
+		"Page title":          true,
+		`Welcome, \"friend\"`: true,
+	}
+	for key := range want {
+		if !keys[key] {
+			t.Errorf("scanTemplateFile did not find key %q in %#v", key, keys)
+		}
+	}
+	if len(keys) != len(want) {
+		t.Errorf("scanTemplateFile found %d keys, want %d: %#v", len(keys), len(want), keys)
+	}
+}
+
+func TestIsTranslationCall(t *testing.T) {
+	src := `package example
+
+func handler() {
+	T("a")
+	i18n.T("b")
+	other.T("c")
+	fmt.Println("d")
+}
+`
+	path := filepath.Join(t.TempDir(), "handler.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	keys := map[string]bool{}
+	if err := scanGoFile(path, keys); err != nil {
+		t.Fatalf("scanGoFile: %v", err)
+	}
+
+	if !keys["a"] || !keys["b"] {
+		t.Errorf("expected bare T() and i18n.T() calls to be recognized, got %#v", keys)
+	}
+	if keys["c"] || keys["d"] {
+		t.Errorf("unrecognized calls should not be recorded, got %#v", keys)
+	}
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormat encodes/decodes a locale file's key -> Message map in a
+// specific on-disk format. All formats share the same key/value model, so
+// a Go server using go-i18n YAML bundles and a frontend using flat JSON can
+// both be driven by the same locale data.
+//
+// Keys may be dotted paths ("auth.login.title"); formats that support real
+// object nesting (json, yaml) encode those as nested objects unless nested
+// is false, and Decode always understands both a nested layout and a flat
+// one with literal dotted keys, so a project can migrate incrementally.
+// Formats without a natural notion of nesting (toml, po) ignore nested and
+// always round-trip dotted keys literally.
+type FileFormat interface {
+	Encode(data map[string]Message, indent string, nested bool) ([]byte, error)
+	Decode(data []byte) (map[string]Message, error)
+}
+
+// newFileFormat resolves the FileFormat named by cfg.Format.
+func newFileFormat(name string) (FileFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "json":
+		return jsonFormat{}, nil
+	case "yaml", "yml":
+		return yamlFormat{}, nil
+	case "toml":
+		return tomlFormat{}, nil
+	case "po", "pot":
+		return poFormat{}, nil
+	default:
+		return nil, fmt.Errorf("unknown file format %q", name)
+	}
+}
+
+// sortedKeys returns data's keys sorted, for deterministic, git-friendly
+// output across every format.
+func sortedKeys(data map[string]Message) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// indentCharacters maps an Indent config value ("2", "4", "tab", or
+// "compact") to the prefix/indent json.Encoder expects.
+func indentCharacters(indent string) (chars string, compact bool) {
+	switch indent {
+	case "tab", "tabs":
+		return "\t", false
+	case "compact":
+		return "", true
+	case "4":
+		return "    ", false
+	default:
+		return "  ", false
+	}
+}
+
+// jsonFormat is the format i18nator always used: a JSON object of
+// key -> string (or, since ICU support landed, key -> string|pluralForms),
+// with dotted keys nested into real JSON objects unless nested is false.
+type jsonFormat struct{}
+
+func (jsonFormat) Encode(data map[string]Message, indent string, nested bool) ([]byte, error) {
+	// Go's json.Marshal/Encoder already emits map keys in sorted order, so
+	// this is deterministic without a separate sort-then-rebuild step.
+	ind, compact := indentCharacters(indent)
+
+	var out interface{} = data
+	if nested {
+		tree, err := nestTree(data)
+		if err != nil {
+			return nil, err
+		}
+		out = tree
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if !compact {
+		enc.SetIndent("", ind)
+	}
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (jsonFormat) Decode(data []byte) (map[string]Message, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := map[string]Message{}
+	if err := flattenTree(raw, "", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// nestTree turns a flat key -> Message map into a nested map[string]interface{}
+// tree by splitting each key on ".", so "auth.login.title" becomes
+// tree["auth"]["login"]["title"]. A key whose path crosses an existing leaf
+// (or vice versa) is a conflicting layout and reported as an error.
+func nestTree(data map[string]Message) (map[string]interface{}, error) {
+	root := map[string]interface{}{}
+
+	for _, key := range sortedKeys(data) {
+		parts := strings.Split(key, ".")
+		node := root
+
+		for i, part := range parts[:len(parts)-1] {
+			existing, ok := node[part]
+			if !ok {
+				child := map[string]interface{}{}
+				node[part] = child
+				node = child
+				continue
+			}
+			child, ok := existing.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key %q conflicts with an existing leaf at %q", key, strings.Join(parts[:i+1], "."))
+			}
+			node = child
+		}
+
+		last := parts[len(parts)-1]
+		if _, isNamespace := node[last].(map[string]interface{}); isNamespace {
+			return nil, fmt.Errorf("key %q conflicts with a nested namespace", key)
+		}
+		node[last] = data[key]
+	}
+
+	return root, nil
+}
+
+// isPluralForms reports whether obj looks like a Message.Forms object
+// (every key a CLDR plural category, every value a string) rather than a
+// nested namespace object, since both are plain JSON/YAML objects on disk.
+func isPluralForms(obj map[string]interface{}) bool {
+	if len(obj) == 0 {
+		return false
+	}
+	for k, v := range obj {
+		if _, ok := cldrOrder[k]; !ok {
+			return false
+		}
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenTree walks a decoded object tree (from json or yaml) and records
+// every leaf as a dotted-path Message in out, so nested namespaces and
+// plain flat dotted keys decode to the same in-memory model.
+func flattenTree(node map[string]interface{}, prefix string, out map[string]Message) error {
+	for key, value := range node {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case string:
+			out[path] = Message{Text: v}
+		case map[string]interface{}:
+			if isPluralForms(v) {
+				forms := make(map[string]string, len(v))
+				for form, text := range v {
+					forms[form] = text.(string)
+				}
+				out[path] = Message{Forms: forms}
+			} else if err := flattenTree(v, path, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: unsupported value %T", path, value)
+		}
+	}
+	return nil
+}
+
+// yamlFormat stores the same key/value model as YAML, for projects using
+// go-i18n-style YAML bundles, nesting dotted keys into mapping nodes unless
+// nested is false.
+type yamlFormat struct{}
+
+func (yamlFormat) Encode(data map[string]Message, indent string, nested bool) ([]byte, error) {
+	var root *yaml.Node
+	var err error
+	if nested {
+		tree, terr := nestTree(data)
+		if terr != nil {
+			return nil, terr
+		}
+		root, err = valueToYAMLNode(tree)
+	} else {
+		root = &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range sortedKeys(data) {
+			var valueNode *yaml.Node
+			valueNode, err = messageToYAMLNode(data[key])
+			if err != nil {
+				break
+			}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	width := 2
+	if indent == "4" {
+		width = 4
+	}
+	enc.SetIndent(width)
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func messageToYAMLNode(m Message) (*yaml.Node, error) {
+	if m.Forms == nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: m.Text}, nil
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, form := range sortedFormKeys(m.Forms) {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: form},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: m.Forms[form]},
+		)
+	}
+	return node, nil
+}
+
+// valueToYAMLNode renders a nestTree node (a Message leaf or a nested
+// map[string]interface{} namespace) as a yaml.Node, recursing into
+// namespaces with sorted keys for deterministic output.
+func valueToYAMLNode(v interface{}) (*yaml.Node, error) {
+	switch val := v.(type) {
+	case Message:
+		return messageToYAMLNode(val)
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child, err := valueToYAMLNode(val[k])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: k}, child)
+		}
+		return node, nil
+	default:
+		return nil, fmt.Errorf("unsupported tree value %T", v)
+	}
+}
+
+func (yamlFormat) Decode(data []byte) (map[string]Message, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := map[string]Message{}
+	if err := flattenTree(raw, "", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// tomlFormat renders keys in a hand-written, deterministic layout (plain
+// values as top-level keys, plural forms as a table), since library
+// Marshal of a Go map doesn't guarantee key order. Dotted keys are always
+// written literally; nested is ignored since TOML's own [table] nesting
+// would collide with the plural-forms table syntax above.
+type tomlFormat struct{}
+
+func (tomlFormat) Encode(data map[string]Message, indent string, nested bool) ([]byte, error) {
+	var buf bytes.Buffer
+	keys := sortedKeys(data)
+	// Plain values are written before any table: once a [table] header is
+	// out, every following key = value line belongs to that table.
+	for _, key := range keys {
+		if m := data[key]; m.Forms == nil {
+			fmt.Fprintf(&buf, "%s = %s\n", strconv.Quote(key), strconv.Quote(m.Text))
+		}
+	}
+	for _, key := range keys {
+		m := data[key]
+		if m.Forms == nil {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "\n[%s]\n", strconv.Quote(key))
+		for _, form := range sortedFormKeys(m.Forms) {
+			fmt.Fprintf(&buf, "%s = %s\n", form, strconv.Quote(m.Forms[form]))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (tomlFormat) Decode(data []byte) (map[string]Message, error) {
+	var raw map[string]interface{}
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]Message, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			result[key] = Message{Text: v}
+		case map[string]interface{}:
+			forms := make(map[string]string, len(v))
+			for form, text := range v {
+				if s, ok := text.(string); ok {
+					forms[form] = s
+				}
+			}
+			result[key] = Message{Forms: forms}
+		default:
+			return nil, fmt.Errorf("%s: unsupported TOML value %T", key, value)
+		}
+	}
+	return result, nil
+}
+
+// poFormat renders a minimal gettext .po/.pot file: msgid/msgstr pairs, and
+// msgid_plural/msgstr[n] for per-form messages. gettext has no notion of
+// key nesting, so dotted keys are always written literally and nested is
+// ignored.
+type poFormat struct{}
+
+func (poFormat) Encode(data map[string]Message, indent string, nested bool) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n\n")
+
+	for _, key := range sortedKeys(data) {
+		m := data[key]
+		fmt.Fprintf(&buf, "msgid %s\n", strconv.Quote(key))
+
+		if m.Forms == nil {
+			fmt.Fprintf(&buf, "msgstr %s\n\n", strconv.Quote(m.Text))
+			continue
+		}
+
+		forms := sortedFormKeys(m.Forms)
+		// Not every language uses all six CLDR categories (English has just
+		// one/other), so record which category each msgstr[n] slot actually
+		// holds rather than assuming the full zero/one/two/few/many/other
+		// order on decode.
+		fmt.Fprintf(&buf, "#. plural-categories: %s\n", strings.Join(forms, ","))
+		fmt.Fprintf(&buf, "msgid_plural %s\n", strconv.Quote(m.Forms["other"]))
+		for i, form := range forms {
+			fmt.Fprintf(&buf, "msgstr[%d] %s\n", i, strconv.Quote(m.Forms[form]))
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// defaultPluralCategories is the fallback msgstr[n] -> category mapping for
+// .po files with no "plural-categories" hint (e.g. hand-written ones), and
+// assumes the full six-category CLDR order.
+var defaultPluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+func (poFormat) Decode(data []byte) (map[string]Message, error) {
+	result := map[string]Message{}
+
+	var key string
+	var forms []string
+	var isPlural bool
+	var categories []string
+
+	flush := func() {
+		if key == "" {
+			return
+		}
+		if !isPlural && len(forms) == 1 {
+			result[key] = Message{Text: forms[0]}
+		} else if len(forms) > 0 {
+			cats := categories
+			if len(cats) == 0 {
+				cats = defaultPluralCategories
+			}
+			parsed := map[string]string{}
+			for i, text := range forms {
+				if i < len(cats) {
+					parsed[cats[i]] = text
+				}
+			}
+			result[key] = Message{Forms: parsed}
+		}
+		key, forms, isPlural, categories = "", nil, false, nil
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#. plural-categories: "):
+			categories = strings.Split(strings.TrimPrefix(line, "#. plural-categories: "), ",")
+		case strings.HasPrefix(line, "msgid_plural "):
+			isPlural = true
+		case strings.HasPrefix(line, "msgid "):
+			flush()
+			key = unquotePO(strings.TrimPrefix(line, "msgid "))
+		case strings.HasPrefix(line, "msgstr["):
+			idx := strings.Index(line, "]")
+			if idx == -1 {
+				continue
+			}
+			n, err := strconv.Atoi(line[len("msgstr["):idx])
+			if err != nil {
+				continue
+			}
+			text := unquotePO(strings.TrimSpace(line[idx+2:]))
+			for len(forms) <= n {
+				forms = append(forms, "")
+			}
+			forms[n] = text
+		case strings.HasPrefix(line, "msgstr "):
+			forms = []string{unquotePO(strings.TrimPrefix(line, "msgstr "))}
+		}
+	}
+	flush()
+
+	delete(result, "")
+	return result, nil
+}
+
+func unquotePO(s string) string {
+	unquoted, err := strconv.Unquote(s)
+	if err != nil {
+		return s
+	}
+	return unquoted
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// roundTripData exercises plain text, per-form plural objects, and dotted
+// keys nested into namespaces, since those are the shapes every FileFormat
+// needs to agree on.
+func roundTripData() map[string]Message {
+	return map[string]Message{
+		"auth.login.title": {Text: "Log in"},
+		"cart.items":       {Forms: map[string]string{"one": "# item", "other": "# items"}},
+	}
+}
+
+func TestFileFormatsRoundTrip(t *testing.T) {
+	formats := map[string]FileFormat{
+		"json": jsonFormat{},
+		"yaml": yamlFormat{},
+		"toml": tomlFormat{},
+		"po":   poFormat{},
+	}
+
+	for name, format := range formats {
+		t.Run(name, func(t *testing.T) {
+			data := roundTripData()
+
+			encoded, err := format.Encode(data, "2", true)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			decoded, err := format.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode: %v\nencoded:\n%s", err, encoded)
+			}
+
+			if !reflect.DeepEqual(decoded, data) {
+				t.Errorf("round-trip = %#v, want %#v\nencoded:\n%s", decoded, data, encoded)
+			}
+		})
+	}
+}
+
+func TestNewFileFormat(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    FileFormat
+		wantErr bool
+	}{
+		{name: "", want: jsonFormat{}},
+		{name: "json", want: jsonFormat{}},
+		{name: "YAML", want: yamlFormat{}},
+		{name: "yml", want: yamlFormat{}},
+		{name: "toml", want: tomlFormat{}},
+		{name: "po", want: poFormat{}},
+		{name: "pot", want: poFormat{}},
+		{name: "xliff", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := newFileFormat(c.name)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("newFileFormat(%q): want error, got nil", c.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newFileFormat(%q): %v", c.name, err)
+			}
+			if got != c.want {
+				t.Errorf("newFileFormat(%q) = %#v, want %#v", c.name, got, c.want)
+			}
+		})
+	}
+}
+
+// TestPOFormatPreservesPluralCategoryOrder pins the plural-categories
+// comment round-trip: without it, po files for languages whose CLDR
+// category set/order differs from defaultPluralCategories (e.g. languages
+// with a "few"/"many" split) would decode their msgstr[n] forms under the
+// wrong category names.
+func TestPOFormatPreservesPluralCategoryOrder(t *testing.T) {
+	data := map[string]Message{
+		"cart.items": {Forms: map[string]string{
+			"one":   "# item",
+			"few":   "# items (few)",
+			"many":  "# items (many)",
+			"other": "# items",
+		}},
+	}
+
+	encoded, err := poFormat{}.Encode(data, "2", true)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	decoded, err := poFormat{}.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode: %v\nencoded:\n%s", err, encoded)
+	}
+
+	if !reflect.DeepEqual(decoded, data) {
+		t.Errorf("round-trip = %#v, want %#v\nencoded:\n%s", decoded, data, encoded)
+	}
+}
+
+func TestJSONFormatFlatVsNested(t *testing.T) {
+	data := map[string]Message{
+		"auth.login.title": {Text: "Log in"},
+	}
+
+	nested, err := jsonFormat{}.Encode(data, "2", true)
+	if err != nil {
+		t.Fatalf("Encode(nested): %v", err)
+	}
+	flat, err := jsonFormat{}.Encode(data, "2", false)
+	if err != nil {
+		t.Fatalf("Encode(flat): %v", err)
+	}
+	if string(nested) == string(flat) {
+		t.Errorf("nested and flat encodings should differ, got identical output:\n%s", nested)
+	}
+
+	for _, encoded := range [][]byte{nested, flat} {
+		decoded, err := jsonFormat{}.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode: %v\nencoded:\n%s", err, encoded)
+		}
+		if !reflect.DeepEqual(decoded, data) {
+			t.Errorf("round-trip = %#v, want %#v\nencoded:\n%s", decoded, data, encoded)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNestTree(t *testing.T) {
+	data := map[string]Message{
+		"auth.login.title": {Text: "Log in"},
+		"auth.login.cta":   {Text: "Go"},
+		"auth.logout":      {Text: "Log out"},
+		"flat":             {Text: "Flat"},
+	}
+
+	tree, err := nestTree(data)
+	if err != nil {
+		t.Fatalf("nestTree: %v", err)
+	}
+
+	auth, ok := tree["auth"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("tree[auth] = %#v, want nested map", tree["auth"])
+	}
+	login, ok := auth["login"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("tree[auth][login] = %#v, want nested map", auth["login"])
+	}
+	if got, want := login["title"], (Message{Text: "Log in"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree[auth][login][title] = %#v, want %#v", got, want)
+	}
+	if got, want := auth["logout"], (Message{Text: "Log out"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree[auth][logout] = %#v, want %#v", got, want)
+	}
+	if got, want := tree["flat"], (Message{Text: "Flat"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree[flat] = %#v, want %#v", got, want)
+	}
+}
+
+// nestTree walks keys in sorted order, so "a" is always placed before "a.b"
+// whichever way the map literal lists them; both cases check that the
+// conflict is reported regardless of how the keys are written.
+func TestNestTreeConflicts(t *testing.T) {
+	cases := []struct {
+		name string
+		data map[string]Message
+	}{
+		{
+			name: "leaf listed first",
+			data: map[string]Message{
+				"a":   {Text: "leaf"},
+				"a.b": {Text: "nested"},
+			},
+		},
+		{
+			name: "namespace listed first",
+			data: map[string]Message{
+				"a.b": {Text: "nested"},
+				"a":   {Text: "leaf"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := nestTree(c.data); err == nil {
+				t.Fatal("nestTree: want error for conflicting key layout, got nil")
+			}
+		})
+	}
+}
+
+func TestFlattenTree(t *testing.T) {
+	raw := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"login": map[string]interface{}{
+				"title": "Log in",
+			},
+		},
+		"cart": map[string]interface{}{
+			"items": map[string]interface{}{
+				"one":   "# item",
+				"other": "# items",
+			},
+		},
+		"flat": "Flat",
+	}
+
+	out := map[string]Message{}
+	if err := flattenTree(raw, "", out); err != nil {
+		t.Fatalf("flattenTree: %v", err)
+	}
+
+	want := map[string]Message{
+		"auth.login.title": {Text: "Log in"},
+		"cart.items":       {Forms: map[string]string{"one": "# item", "other": "# items"}},
+		"flat":             {Text: "Flat"},
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("flattenTree = %#v, want %#v", out, want)
+	}
+}
+
+// TestNestFlattenRoundTrip guards against nestTree/flattenTree drifting out
+// of sync with each other, since every tree-based FileFormat relies on them
+// being inverses of one another.
+func TestNestFlattenRoundTrip(t *testing.T) {
+	data := map[string]Message{
+		"auth.login.title": {Text: "Log in"},
+		"auth.login.cta":   {Text: "Go"},
+		"cart.items":       {Forms: map[string]string{"one": "# item", "other": "# items"}},
+		"flat":             {Text: "Flat"},
+	}
+
+	tree, err := nestTree(data)
+	if err != nil {
+		t.Fatalf("nestTree: %v", err)
+	}
+
+	// flattenTree expects the decoded-JSON/YAML shape (map[string]interface{}
+	// leaves, not Message), so convert the way Encode/Decode would via JSON.
+	raw := map[string]interface{}{}
+	var convert func(map[string]interface{}) map[string]interface{}
+	convert = func(node map[string]interface{}) map[string]interface{} {
+		out := map[string]interface{}{}
+		for k, v := range node {
+			switch val := v.(type) {
+			case Message:
+				if val.Forms != nil {
+					forms := map[string]interface{}{}
+					for form, text := range val.Forms {
+						forms[form] = text
+					}
+					out[k] = forms
+				} else {
+					out[k] = val.Text
+				}
+			case map[string]interface{}:
+				out[k] = convert(val)
+			}
+		}
+		return out
+	}
+	raw = convert(tree)
+
+	out := map[string]Message{}
+	if err := flattenTree(raw, "", out); err != nil {
+		t.Fatalf("flattenTree: %v", err)
+	}
+	if !reflect.DeepEqual(out, data) {
+		t.Errorf("round-trip = %#v, want %#v", out, data)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newFmtCmd() *cobra.Command {
+	var flat bool
+
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Rewrite all locale files in canonical form",
+		Long:  "Re-encodes every configured locale file with sorted keys and the configured indent, so edits produce clean, minimal git diffs. Dotted keys nest into objects unless cfg.FlatKeys is set or --flat is given; --flat forces the flat layout for this run even when cfg.FlatKeys is false",
+		Run: func(cmd *cobra.Command, args []string) {
+			runFmt(flat)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flat, "flat", false, "write dotted keys flat instead of as nested objects, regardless of cfg.FlatKeys")
+
+	return cmd
+}
+
+func runFmt(flat bool) {
+	if err := initEnv(); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	nested := !cfg.FlatKeys && !flat
+
+	for _, lang := range cfg.TargetLanguages {
+		file := cfg.LocaleFile(lang)
+		path := filepath.Join(basePath, file)
+
+		data := loadLocaleFile(path)
+		if err := saveLocaleFileAs(path, data, nested); err != nil {
+			fmt.Printf("❌ %s: failed to format (%v)\n", file, err)
+			continue
+		}
+		fmt.Printf("✅ %s: formatted\n", file)
+	}
+
+	fmt.Println("\n✨ fmt complete!")
+}
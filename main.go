@@ -1,11 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,14 +10,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Language configuration
-var languages = map[string]string{
-	"en.json": "en",
-	"km.json": "km",
-	"zh.json": "zh-CN",
-}
-
-var basePath string
+var (
+	cfg      *Config
+	basePath string
+	provider TranslationProvider
+)
 
 func main() {
 	var rootCmd = &cobra.Command{
@@ -30,43 +23,17 @@ func main() {
 		Long:  "i18nator helps you manage internationalization files with automatic translation support",
 	}
 
-	// Add command
-	var addCmd = &cobra.Command{
-		Use:   "add [key] [value]",
-		Short: "Add a new i18n key with automatic translation",
-		Long:  "Add a new key-value pair to all language files with automatic translation",
-		Args:  cobra.ExactArgs(2),
-		Run:   runAdd,
-	}
-
-	// List command
-	var listCmd = &cobra.Command{
-		Use:   "list",
-		Short: "List all i18n keys",
-		Long:  "Display all i18n keys from the English language file",
-		Run:   runList,
-	}
-
-	// Update command
-	var updateCmd = &cobra.Command{
-		Use:   "update [key] [value]",
-		Short: "Update an existing i18n key",
-		Long:  "Update an existing key-value pair in all language files with automatic translation",
-		Args:  cobra.ExactArgs(2),
-		Run:   runUpdate,
-	}
-
 	// Remove command
 	var removeCmd = &cobra.Command{
 		Use:   "remove [key]",
 		Short: "Remove an i18n key from all languages",
-		Long:  "Remove a key-value pair from all language files",
+		Long:  "Remove a key-value pair from all language files. Dotted keys (\"auth.login.title\") are treated as namespace paths, and since nesting is derived from the flat key set at encode time, removing the last key under a prefix automatically drops the now-empty parent object",
 		Args:  cobra.ExactArgs(1),
 		Run:   runRemove,
 	}
 
 	// Add commands to root
-	rootCmd.AddCommand(addCmd, listCmd, updateCmd, removeCmd)
+	rootCmd.AddCommand(newAddCmd(), newListCmd(), newUpdateCmd(), removeCmd, newExtractCmd(), newPushCmd(), newPullCmd(), newRetranslateCmd(), newFmtCmd())
 
 	// Execute
 	if err := rootCmd.Execute(); err != nil {
@@ -75,59 +42,128 @@ func main() {
 	}
 }
 
-// Initialize base path
-func initBasePath() error {
-	cwd, err := os.Getwd()
+// initEnv loads the .i18nator config and provider with default batching
+// parameters, for commands that don't expose --concurrency/--rps/--no-cache.
+func initEnv() error {
+	return initEnvWithOptions(defaultRPS, true)
+}
+
+// initEnvWithOptions loads the .i18nator config, resolves the locales
+// directory, and builds a caching/rate-limited Translator around the
+// configured provider.
+func initEnvWithOptions(rps float64, useCache bool) error {
+	var err error
+
+	cfg, err = loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %v", err)
+		return err
+	}
+
+	basePath, err = cfg.LocalesPath()
+	if err != nil {
+		return err
 	}
-	basePath = filepath.Join(cwd, "pkg", "translates", "localize", "i18n")
 
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create directory: %v", err)
 	}
-
 	fmt.Printf("📂 Using base path: %s\n", basePath)
+
+	baseProvider, err := newProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init translation provider: %v", err)
+	}
+	provider = newTranslator(baseProvider, rps, useCache)
+
 	return nil
 }
 
+// saveTranslatorCache persists any new cache entries the current provider
+// accumulated, if it's a cache-aware Translator.
+func saveTranslatorCache() {
+	if t, ok := provider.(*Translator); ok {
+		if err := t.save(); err != nil {
+			fmt.Printf("⚠️  Failed to write translation cache: %v\n", err)
+		}
+	}
+}
+
+func newAddCmd() *cobra.Command {
+	var concurrency int
+	var rps float64
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "add [key] [value]",
+		Short: "Add a new i18n key with automatic translation",
+		Long:  "Add a new key-value pair to all language files with automatic translation",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runAdd(args[0], args[1], concurrency, rps, noCache)
+		},
+	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultConcurrency, "number of languages to translate concurrently")
+	cmd.Flags().Float64Var(&rps, "rps", defaultRPS, "max translation requests per second")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "skip the local translation cache")
+
+	return cmd
+}
+
 // Add command handler
-func runAdd(cmd *cobra.Command, args []string) {
-	if err := initBasePath(); err != nil {
+func runAdd(key, value string, concurrency int, rps float64, noCache bool) {
+	if err := initEnvWithOptions(rps, !noCache); err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		return
 	}
 
-	key := args[0]
-	value := args[1]
+	msg := Message{Text: value}
 
 	// Check if key already exists
-	enPath := filepath.Join(basePath, "en.json")
-	enData := loadJSON(enPath)
-	if _, exists := enData[key]; exists {
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+	if _, exists := sourceData[key]; exists {
 		fmt.Printf("⚠️  Key '%s' already exists. Use 'update' command to modify it.\n", key)
 		return
 	}
 
 	fmt.Println()
-	for file, langCode := range languages {
-		path := filepath.Join(basePath, file)
-		processAddOrUpdate(path, key, value, file == "en.json", langCode)
-	}
+	forEachLanguage(cfg.TargetLanguages, concurrency, func(lang LanguageConfig) {
+		path := filepath.Join(basePath, cfg.LocaleFile(lang))
+		processAddOrUpdate(path, key, msg, lang.Code == cfg.SourceLanguage, lang.Code)
+	})
+	saveTranslatorCache()
 
 	fmt.Println("\n✨ i18n key added to all languages!")
 }
 
+func newListCmd() *cobra.Command {
+	var flat bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all i18n keys",
+		Long:  "Display all i18n keys from the source language file, rendered as an indented tree of dotted namespaces unless --flat is given or cfg.FlatKeys is set",
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(flat)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flat, "flat", false, "list dotted keys flat instead of as a nested tree")
+
+	return cmd
+}
+
 // List command handler
-func runList(cmd *cobra.Command, args []string) {
-	if err := initBasePath(); err != nil {
+func runList(flat bool) {
+	if err := initEnv(); err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		return
 	}
 
-	enPath := filepath.Join(basePath, "en.json")
-	data := loadJSON(enPath)
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	data := loadLocaleFile(sourcePath)
 
 	if len(data) == 0 {
 		fmt.Println("📭 No i18n keys found")
@@ -136,49 +172,95 @@ func runList(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("📋 Found %d i18n keys:\n\n", len(data))
 
-	// Sort keys
-	keys := make([]string, 0, len(data))
-	for k := range data {
+	if flat || cfg.FlatKeys {
+		for _, k := range sortedKeys(data) {
+			fmt.Printf("  %s: %s\n", k, data[k])
+		}
+		return
+	}
+
+	tree, err := nestTree(data)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+	printTree(tree, 1)
+}
+
+// printTree renders a nestTree result as an indented key/value tree, used by
+// `list` to display dotted-path keys the way they'd appear in the on-disk
+// nested format.
+func printTree(node map[string]interface{}, depth int) {
+	keys := make([]string, 0, len(node))
+	for k := range node {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	// Display
+	indent := strings.Repeat("  ", depth)
 	for _, k := range keys {
-		fmt.Printf("  %s: %s\n", k, data[k])
+		switch v := node[k].(type) {
+		case Message:
+			fmt.Printf("%s%s: %s\n", indent, k, v)
+		case map[string]interface{}:
+			fmt.Printf("%s%s:\n", indent, k)
+			printTree(v, depth+1)
+		}
+	}
+}
+
+func newUpdateCmd() *cobra.Command {
+	var concurrency int
+	var rps float64
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "update [key] [value]",
+		Short: "Update an existing i18n key",
+		Long:  "Update an existing key-value pair in all language files with automatic translation",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runUpdate(args[0], args[1], concurrency, rps, noCache)
+		},
 	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultConcurrency, "number of languages to translate concurrently")
+	cmd.Flags().Float64Var(&rps, "rps", defaultRPS, "max translation requests per second")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "skip the local translation cache")
+
+	return cmd
 }
 
 // Update command handler
-func runUpdate(cmd *cobra.Command, args []string) {
-	if err := initBasePath(); err != nil {
+func runUpdate(key, value string, concurrency int, rps float64, noCache bool) {
+	if err := initEnvWithOptions(rps, !noCache); err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		return
 	}
 
-	key := args[0]
-	value := args[1]
+	msg := Message{Text: value}
 
 	// Check if key exists
-	enPath := filepath.Join(basePath, "en.json")
-	enData := loadJSON(enPath)
-	if _, exists := enData[key]; !exists {
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+	if _, exists := sourceData[key]; !exists {
 		fmt.Printf("⚠️  Key '%s' does not exist. Use 'add' command to create it.\n", key)
 		return
 	}
 
 	fmt.Println()
-	for file, langCode := range languages {
-		path := filepath.Join(basePath, file)
-		processAddOrUpdate(path, key, value, file == "en.json", langCode)
-	}
+	forEachLanguage(cfg.TargetLanguages, concurrency, func(lang LanguageConfig) {
+		path := filepath.Join(basePath, cfg.LocaleFile(lang))
+		processAddOrUpdate(path, key, msg, lang.Code == cfg.SourceLanguage, lang.Code)
+	})
+	saveTranslatorCache()
 
 	fmt.Println("\n✨ i18n key updated in all languages!")
 }
 
 // Remove command handler
 func runRemove(cmd *cobra.Command, args []string) {
-	if err := initBasePath(); err != nil {
+	if err := initEnv(); err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		return
 	}
@@ -186,21 +268,25 @@ func runRemove(cmd *cobra.Command, args []string) {
 	key := args[0]
 
 	// Check if key exists
-	enPath := filepath.Join(basePath, "en.json")
-	enData := loadJSON(enPath)
-	if _, exists := enData[key]; !exists {
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+	if _, exists := sourceData[key]; !exists {
 		fmt.Printf("⚠️  Key '%s' does not exist\n", key)
 		return
 	}
 
 	fmt.Println()
-	for file := range languages {
+	for _, lang := range cfg.TargetLanguages {
+		file := cfg.LocaleFile(lang)
 		path := filepath.Join(basePath, file)
-		data := loadJSON(path)
+		data := loadLocaleFile(path)
 
 		if _, exists := data[key]; exists {
 			delete(data, key)
-			saveJSON(path, data)
+			if err := saveLocaleFile(path, data); err != nil {
+				fmt.Printf("❌ %s: failed to save: %v\n", file, err)
+				continue
+			}
 			fmt.Printf("✅ %s: Key removed\n", file)
 		} else {
 			fmt.Printf("⏭️  %s: Key not found\n", file)
@@ -211,57 +297,77 @@ func runRemove(cmd *cobra.Command, args []string) {
 }
 
 // Process add or update operation
-func processAddOrUpdate(path, key, value string, isEnglish bool, langCode string) {
-	data := loadJSON(path)
+func processAddOrUpdate(path string, key string, value Message, isSource bool, langCode string) {
+	data := loadLocaleFile(path)
 
-	if isEnglish {
+	if isSource {
 		data[key] = value
-		saveJSON(path, data)
+		if err := saveLocaleFile(path, data); err != nil {
+			fmt.Printf("❌ %s: failed to save: %v\n", filepath.Base(path), err)
+			return
+		}
 		fmt.Printf("✅ %s: %s\n", filepath.Base(path), value)
+		return
+	}
+
+	translated := value
+	if override, ok := cfg.Overrides[langCode][key]; ok {
+		translated = Message{Text: override}
+	} else if t, err := translateMessage(value, provider, langCode); err != nil {
+		fmt.Printf("❌ %s: Translation failed (%v), using source text\n", filepath.Base(path), err)
+	} else if err := validateMessage(key, value, t); err != nil {
+		fmt.Printf("❌ %s: %v, using source text\n", filepath.Base(path), err)
 	} else {
-		translated, err := googleTranslate(value, langCode)
-		if err != nil {
-			fmt.Printf("❌ %s: Translation failed (%v), using English\n", filepath.Base(path), err)
-			translated = value
-		}
-		data[key] = translated
-		saveJSON(path, data)
-		fmt.Printf("✅ %s: %s\n", filepath.Base(path), translated)
+		translated = t
+	}
+
+	data[key] = translated
+	if err := saveLocaleFile(path, data); err != nil {
+		fmt.Printf("❌ %s: failed to save: %v\n", filepath.Base(path), err)
+		return
 	}
+	fmt.Printf("✅ %s: %s\n", filepath.Base(path), translated)
 }
 
-// Load JSON file
-func loadJSON(path string) map[string]string {
+// loadLocaleFile reads and decodes a locale file in the configured format.
+func loadLocaleFile(path string) map[string]Message {
 	fileData, err := os.ReadFile(path)
 	if err != nil {
-		return map[string]string{}
+		return map[string]Message{}
 	}
 
-	var data map[string]string
-	if err := json.Unmarshal(fileData, &data); err != nil {
-		return map[string]string{}
+	format, err := newFileFormat(cfg.Format)
+	if err != nil {
+		return map[string]Message{}
 	}
 
+	data, err := format.Decode(fileData)
+	if err != nil {
+		return map[string]Message{}
+	}
 	if data == nil {
-		data = map[string]string{}
+		data = map[string]Message{}
 	}
 	return data
 }
 
-// Save JSON file with sorted keys
-func saveJSON(path string, data map[string]string) error {
-	keys := make([]string, 0, len(data))
-	for k := range data {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
+// saveLocaleFile encodes data in the configured format, honoring
+// cfg.FlatKeys, and writes it atomically: encode to path+".tmp", then
+// rename over path, so a crash mid-write never corrupts the existing file.
+func saveLocaleFile(path string, data map[string]Message) error {
+	return saveLocaleFileAs(path, data, !cfg.FlatKeys)
+}
 
-	sorted := make(map[string]string)
-	for _, k := range keys {
-		sorted[k] = data[k]
+// saveLocaleFileAs is saveLocaleFile with an explicit nested/flat override,
+// for callers like `fmt --flat` that need to rewrite a file in a layout
+// other than cfg.FlatKeys.
+func saveLocaleFileAs(path string, data map[string]Message, nested bool) error {
+	format, err := newFileFormat(cfg.Format)
+	if err != nil {
+		return err
 	}
 
-	jsonBytes, err := json.MarshalIndent(sorted, "", "  ")
+	encoded, err := format.Encode(data, cfg.Indent, nested)
 	if err != nil {
 		return err
 	}
@@ -270,59 +376,9 @@ func saveJSON(path string, data map[string]string) error {
 		return err
 	}
 
-	return os.WriteFile(path, jsonBytes, 0644)
-}
-
-// Google Translate using free API
-func googleTranslate(text, targetLang string) (string, error) {
-	baseURL := "https://translate.googleapis.com/translate_a/single"
-
-	params := url.Values{}
-	params.Add("client", "gtx")
-	params.Add("sl", "en")
-	params.Add("tl", targetLang)
-	params.Add("dt", "t")
-	params.Add("q", text)
-
-	fullURL := baseURL + "?" + params.Encode()
-
-	resp, err := http.Get(fullURL)
-	if err != nil {
-		return "", fmt.Errorf("request failed: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %v", err)
-	}
-
-	var result []interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to parse response: %v", err)
-	}
-
-	if len(result) == 0 {
-		return "", fmt.Errorf("empty response")
-	}
-
-	translations, ok := result[0].([]interface{})
-	if !ok || len(translations) == 0 {
-		return "", fmt.Errorf("invalid response format")
-	}
-
-	var translatedText strings.Builder
-	for _, item := range translations {
-		if arr, ok := item.([]interface{}); ok && len(arr) > 0 {
-			if str, ok := arr[0].(string); ok {
-				translatedText.WriteString(str)
-			}
-		}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, encoded, 0644); err != nil {
+		return err
 	}
-
-	return strings.TrimSpace(translatedText.String()), nil
+	return os.Rename(tmpPath, path)
 }
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Message is a single i18n entry. It unmarshals from either a plain JSON
+// string — which may itself be an ICU MessageFormat pattern such as
+// "You have {count, plural, one {# item} other {# items}}" — or a JSON
+// object keyed by CLDR plural categories (zero/one/two/few/many/other),
+// for projects that prefer to spell out each form explicitly.
+type Message struct {
+	Text  string
+	Forms map[string]string
+}
+
+func (m Message) MarshalJSON() ([]byte, error) {
+	if m.Forms != nil {
+		return json.Marshal(m.Forms)
+	}
+	return json.Marshal(m.Text)
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = Message{Text: text}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be a string or a plural-form object: %v", err)
+	}
+	*m = Message{Forms: forms}
+	return nil
+}
+
+// UnmarshalYAML mirrors UnmarshalJSON for the YAML file format.
+func (m *Message) UnmarshalYAML(node *yaml.Node) error {
+	var text string
+	if err := node.Decode(&text); err == nil {
+		*m = Message{Text: text}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := node.Decode(&forms); err != nil {
+		return fmt.Errorf("message must be a string or a plural-form object: %v", err)
+	}
+	*m = Message{Forms: forms}
+	return nil
+}
+
+// String renders a Message for display in `list` output.
+func (m Message) String() string {
+	if m.Forms == nil {
+		return m.Text
+	}
+
+	keys := sortedFormKeys(m.Forms)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s: %s", k, m.Forms[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedFormKeys returns forms' keys in CLDR plural-category order
+// (zero/one/two/few/many/other).
+func sortedFormKeys(forms map[string]string) []string {
+	keys := make([]string, 0, len(forms))
+	for k := range forms {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return cldrOrder[keys[i]] < cldrOrder[keys[j]] })
+	return keys
+}
+
+var (
+	placeholderRe = regexp.MustCompile(`\{(\w+)\}`)
+	// icuPluralRe finds an ICU plural clause anywhere in a message's text,
+	// not just when it's the whole string, since real messages usually wrap
+	// it in a natural-language sentence (e.g. "You have {count, plural, ...}").
+	icuPluralRe = regexp.MustCompile(`\{(\w+),\s*plural,\s*((?:(?:zero|one|two|few|many|other)\s*\{[^{}]*\}\s*)+)\}`)
+	icuBranchRe = regexp.MustCompile(`(zero|one|two|few|many|other)\s*\{([^{}]*)\}`)
+)
+
+// icuHashToken is a placeholder-shaped stand-in for the bare ICU "#" token
+// ("# item(s)") while a branch is run through translateSegments, so the
+// existing {placeholder}-preservation logic protects it too.
+const icuHashToken = "{icuhash}"
+
+// Equal reports whether two messages are value-equal. Used by `pull` to
+// detect whether a remote translation actually changed a local one.
+func (m Message) Equal(other Message) bool {
+	if m.Text != other.Text || len(m.Forms) != len(other.Forms) {
+		return false
+	}
+	for form, text := range m.Forms {
+		if other.Forms[form] != text {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholders returns every named placeholder ("{name}") and ICU plural
+// argument referenced by the message, across all of its forms, plus "#"
+// when an ICU plural clause's branches use it, so validateMessage can catch
+// a translation that drops any of them. A "#" outside an ICU plural clause
+// (e.g. in a per-form object's text) is not recorded.
+func (m Message) placeholders() map[string]bool {
+	names := map[string]bool{}
+	for _, text := range m.texts() {
+		for _, match := range icuPluralRe.FindAllStringSubmatch(text, -1) {
+			names[match[1]] = true
+			if strings.Contains(match[2], "#") {
+				names["#"] = true
+			}
+		}
+		for _, ph := range placeholderRe.FindAllStringSubmatch(text, -1) {
+			names[ph[1]] = true
+		}
+	}
+	return names
+}
+
+// texts returns every raw string carried by the message: Text itself, or
+// every value in Forms.
+func (m Message) texts() []string {
+	if m.Forms != nil {
+		texts := make([]string, 0, len(m.Forms))
+		for _, t := range m.Forms {
+			texts = append(texts, t)
+		}
+		return texts
+	}
+	return []string{m.Text}
+}
+
+// translateMessage translates m's human-readable segments into targetLang,
+// leaving placeholders and ICU plural structure intact. For plain-string
+// plural patterns it regenerates the branch set required by targetLang's
+// CLDR plural rules.
+func translateMessage(m Message, provider TranslationProvider, targetLang string) (Message, error) {
+	switch {
+	case m.Forms != nil:
+		translated := make(map[string]string, len(m.Forms))
+		for form, text := range m.Forms {
+			t, err := translateICUBranch(text, provider, targetLang)
+			if err != nil {
+				return Message{}, err
+			}
+			translated[form] = t
+		}
+		return Message{Forms: translated}, nil
+
+	case icuPluralRe.MatchString(m.Text):
+		return translateICUPlural(m.Text, provider, targetLang)
+
+	default:
+		t, err := translateSegments(m.Text, provider, targetLang)
+		if err != nil {
+			return Message{}, err
+		}
+		return Message{Text: t}, nil
+	}
+}
+
+// translateICUPlural translates the natural-language text surrounding an
+// embedded ICU plural clause plus the branch text within it, and
+// regenerates the branch set to match targetLang's plural categories,
+// reusing the "other" branch (or the first available one) as source text
+// for any category the original pattern didn't have. The bare "#" token
+// inside a branch is protected from the provider and restored verbatim.
+func translateICUPlural(text string, provider TranslationProvider, targetLang string) (Message, error) {
+	loc := icuPluralRe.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return Message{}, fmt.Errorf("not an ICU plural pattern: %s", text)
+	}
+	arg := text[loc[2]:loc[3]]
+	prefix, suffix := text[:loc[0]], text[loc[1]:]
+
+	branches := map[string]string{}
+	for _, b := range icuBranchRe.FindAllStringSubmatch(text[loc[4]:loc[5]], -1) {
+		branches[b[1]] = strings.TrimSpace(b[2])
+	}
+
+	source := branches["other"]
+	for _, form := range []string{"one", "zero", "two", "few", "many"} {
+		if source != "" {
+			break
+		}
+		source = branches[form]
+	}
+
+	translated := map[string]string{}
+	for _, form := range pluralCategoriesFor(targetLang) {
+		branch := branches[form]
+		if branch == "" {
+			branch = source
+		}
+		t, err := translateICUBranch(branch, provider, targetLang)
+		if err != nil {
+			return Message{}, err
+		}
+		translated[form] = t
+	}
+
+	var parts []string
+	for _, form := range []string{"zero", "one", "two", "few", "many", "other"} {
+		if t, ok := translated[form]; ok {
+			parts = append(parts, fmt.Sprintf("%s {%s}", form, t))
+		}
+	}
+	plural := fmt.Sprintf("{%s, plural, %s}", arg, strings.Join(parts, " "))
+
+	translatedPrefix, err := translateSegments(prefix, provider, targetLang)
+	if err != nil {
+		return Message{}, err
+	}
+	translatedSuffix, err := translateSegments(suffix, provider, targetLang)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Text: translatedPrefix + plural + translatedSuffix}, nil
+}
+
+// translateICUBranch translates a single plural branch's text, substituting
+// the bare "#" token for a placeholder-shaped sentinel first so
+// translateSegments' existing {placeholder} preservation protects it too.
+func translateICUBranch(text string, provider TranslationProvider, targetLang string) (string, error) {
+	protected := strings.ReplaceAll(text, "#", icuHashToken)
+	translated, err := translateSegments(protected, provider, targetLang)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(translated, icuHashToken, "#"), nil
+}
+
+// translateSegments sends only the human-text segments of text to the
+// provider, leaving {placeholder} tokens untouched. A bare ICU "#" is not
+// protected here; translateICUBranch swaps it for icuHashToken first.
+func translateSegments(text string, provider TranslationProvider, targetLang string) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringIndex(text, -1) {
+		if segment := text[last:loc[0]]; strings.TrimSpace(segment) != "" {
+			t, err := provider.Translate(segment, targetLang)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(t)
+		} else {
+			b.WriteString(segment)
+		}
+		b.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+
+	if remainder := text[last:]; strings.TrimSpace(remainder) != "" {
+		t, err := provider.Translate(remainder, targetLang)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(t)
+	} else {
+		b.WriteString(remainder)
+	}
+
+	return b.String(), nil
+}
+
+// validateMessage fails with an error describing the mismatch when a
+// translated message's placeholder set diverges from the source message's.
+func validateMessage(key string, source, translated Message) error {
+	want := source.placeholders()
+	got := translated.placeholders()
+
+	var missing, extra []string
+	for name := range want {
+		if !got[name] {
+			missing = append(missing, name)
+		}
+	}
+	for name := range got {
+		if !want[name] {
+			extra = append(extra, name)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(extra)
+	return fmt.Errorf("placeholder mismatch for %q: missing %v, unexpected %v", key, missing, extra)
+}
+
+var cldrOrder = map[string]int{"zero": 0, "one": 1, "two": 2, "few": 3, "many": 4, "other": 5}
+
+// pluralCategoriesFor returns the CLDR plural categories a language
+// actually distinguishes, by probing plural.Cardinal over a representative
+// sample of integers and collecting the distinct forms it reports — e.g.
+// Khmer and Chinese collapse to just "other", while English keeps
+// "one"/"other".
+func pluralCategoriesFor(langCode string) []string {
+	tag, err := language.Parse(langCode)
+	if err != nil {
+		return []string{"one", "other"}
+	}
+
+	seen := map[string]bool{}
+	var forms []string
+	for n := 0; n <= 100; n++ {
+		form := pluralFormName(plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0))
+		if !seen[form] {
+			seen[form] = true
+			forms = append(forms, form)
+		}
+	}
+
+	sort.Slice(forms, func(i, j int) bool { return cldrOrder[forms[i]] < cldrOrder[forms[j]] })
+	return forms
+}
+
+func pluralFormName(f plural.Form) string {
+	switch f {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
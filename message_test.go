@@ -0,0 +1,128 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// markerProvider wraps whatever text it's asked to translate in angle
+// brackets, so tests can tell which parts of a message were actually sent
+// to the provider and which were left untouched (placeholders, the ICU "#"
+// token, whitespace-only segments).
+type markerProvider struct{}
+
+func (markerProvider) Translate(text, targetLang string) (string, error) {
+	return "<" + text + ">", nil
+}
+
+func TestTranslateMessagePlainText(t *testing.T) {
+	got, err := translateMessage(Message{Text: "Hello {name}!"}, markerProvider{}, "fr")
+	if err != nil {
+		t.Fatalf("translateMessage: %v", err)
+	}
+	want := Message{Text: "<Hello >{name}<!>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("translateMessage = %#v, want %#v", got, want)
+	}
+}
+
+func TestTranslateMessageICUPluralEmbedded(t *testing.T) {
+	source := Message{Text: "You have {count, plural, one {# item} other {# items}} in your cart"}
+
+	got, err := translateMessage(source, markerProvider{}, "fr")
+	if err != nil {
+		t.Fatalf("translateMessage: %v", err)
+	}
+
+	if !strings.Contains(got.Text, "{count, plural,") {
+		t.Fatalf("translateMessage result lost the ICU plural clause: %q", got.Text)
+	}
+	if !strings.Contains(got.Text, "# item") && !strings.Contains(got.Text, "#") {
+		t.Errorf("translateMessage result should still contain the bare # token, got %q", got.Text)
+	}
+	// The marker provider only ever sees text with "#" already swapped for
+	// icuHashToken, so a literal "#" surviving translation round-trips back
+	// in place rather than being sent to (and possibly mangled by) the
+	// provider.
+	if strings.Contains(got.Text, icuHashToken) {
+		t.Errorf("translateMessage leaked the internal icuHashToken sentinel: %q", got.Text)
+	}
+	if !strings.Contains(got.Text, "<You have >") {
+		t.Errorf("translateMessage should translate the prefix surrounding the plural clause, got %q", got.Text)
+	}
+	if !strings.Contains(got.Text, "< in your cart>") {
+		t.Errorf("translateMessage should translate the suffix surrounding the plural clause, got %q", got.Text)
+	}
+}
+
+func TestTranslateMessagePluralForms(t *testing.T) {
+	source := Message{Forms: map[string]string{
+		"one":   "# item",
+		"other": "# items",
+	}}
+
+	got, err := translateMessage(source, markerProvider{}, "fr")
+	if err != nil {
+		t.Fatalf("translateMessage: %v", err)
+	}
+	if got.Forms == nil {
+		t.Fatalf("translateMessage result has no Forms: %#v", got)
+	}
+	for form, text := range got.Forms {
+		if !strings.Contains(text, "#") {
+			t.Errorf("form %q lost its bare # token: %q", form, text)
+		}
+		if strings.Contains(text, icuHashToken) {
+			t.Errorf("form %q leaked the internal icuHashToken sentinel: %q", form, text)
+		}
+	}
+}
+
+func TestValidateMessagePlaceholderMismatch(t *testing.T) {
+	source := Message{Text: "Hello {name}, you have {count} messages"}
+
+	cases := []struct {
+		name       string
+		translated Message
+		wantErr    bool
+	}{
+		{name: "matching placeholders", translated: Message{Text: "Bonjour {name}, vous avez {count} messages"}},
+		{name: "missing placeholder", translated: Message{Text: "Bonjour {name}"}, wantErr: true},
+		{name: "extra placeholder", translated: Message{Text: "Bonjour {name}, {count}, {extra}"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateMessage("greeting", source, c.translated)
+			if c.wantErr && err == nil {
+				t.Fatal("validateMessage: want error, got nil")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("validateMessage: %v", err)
+			}
+		})
+	}
+}
+
+func TestMessageEqual(t *testing.T) {
+	a := Message{Text: "hi"}
+	b := Message{Text: "hi"}
+	c := Message{Text: "bye"}
+	if !a.Equal(b) {
+		t.Errorf("%#v.Equal(%#v) = false, want true", a, b)
+	}
+	if a.Equal(c) {
+		t.Errorf("%#v.Equal(%#v) = true, want false", a, c)
+	}
+
+	forms1 := Message{Forms: map[string]string{"one": "# item", "other": "# items"}}
+	forms2 := Message{Forms: map[string]string{"one": "# item", "other": "# items"}}
+	forms3 := Message{Forms: map[string]string{"one": "# item", "other": "# stuff"}}
+	if !forms1.Equal(forms2) {
+		t.Errorf("%#v.Equal(%#v) = false, want true", forms1, forms2)
+	}
+	if forms1.Equal(forms3) {
+		t.Errorf("%#v.Equal(%#v) = true, want false", forms1, forms3)
+	}
+}
@@ -0,0 +1,461 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TranslationProvider translates a single string from the configured source
+// language into a target locale.
+type TranslationProvider interface {
+	Translate(text, targetLang string) (string, error)
+}
+
+// BatchTranslationProvider lets a provider translate multiple independent
+// strings in a single request. Translator uses this when the underlying
+// provider implements it, falling back to one-by-one Translate calls
+// otherwise.
+type BatchTranslationProvider interface {
+	TranslateBatch(texts []string, targetLang string) ([]string, error)
+}
+
+// newProvider builds the TranslationProvider named by cfg.Provider, reading
+// any required credentials from environment variables.
+func newProvider(cfg *Config) (TranslationProvider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", "google-free":
+		return &googleFreeProvider{sourceLang: cfg.SourceLanguage}, nil
+
+	case "google-v2":
+		apiKey := os.Getenv("GOOGLE_TRANSLATE_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("GOOGLE_TRANSLATE_API_KEY is required for provider %q", cfg.Provider)
+		}
+		return &googleV2Provider{apiKey: apiKey}, nil
+
+	case "deepl":
+		apiKey := os.Getenv("DEEPL_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("DEEPL_API_KEY is required for provider %q", cfg.Provider)
+		}
+		return &deeplProvider{apiKey: apiKey}, nil
+
+	case "azure":
+		apiKey := os.Getenv("AZURE_TRANSLATOR_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("AZURE_TRANSLATOR_KEY is required for provider %q", cfg.Provider)
+		}
+		return &azureProvider{apiKey: apiKey, region: os.Getenv("AZURE_TRANSLATOR_REGION")}, nil
+
+	case "libretranslate":
+		endpoint := os.Getenv("LIBRETRANSLATE_URL")
+		if endpoint == "" {
+			endpoint = "https://libretranslate.com/translate"
+		}
+		return &libreTranslateProvider{endpoint: endpoint, apiKey: os.Getenv("LIBRETRANSLATE_API_KEY"), sourceLang: cfg.SourceLanguage}, nil
+
+	case "noop", "copy-source":
+		return &noopProvider{}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown translation provider %q", cfg.Provider)
+	}
+}
+
+// googleFreeProvider uses Google's unofficial, keyless translate endpoint —
+// the same one i18nator always used.
+type googleFreeProvider struct {
+	sourceLang string
+}
+
+func (p *googleFreeProvider) Translate(text, targetLang string) (string, error) {
+	baseURL := "https://translate.googleapis.com/translate_a/single"
+
+	params := url.Values{}
+	params.Add("client", "gtx")
+	params.Add("sl", p.sourceLang)
+	params.Add("tl", targetLang)
+	params.Add("dt", "t")
+	params.Add("q", text)
+
+	resp, err := http.Get(baseURL + "?" + params.Encode())
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %v", err)
+	}
+
+	var result []interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+
+	if len(result) == 0 {
+		return "", fmt.Errorf("empty response")
+	}
+
+	translations, ok := result[0].([]interface{})
+	if !ok || len(translations) == 0 {
+		return "", fmt.Errorf("invalid response format")
+	}
+
+	var translatedText strings.Builder
+	for _, item := range translations {
+		if arr, ok := item.([]interface{}); ok && len(arr) > 0 {
+			if str, ok := arr[0].(string); ok {
+				translatedText.WriteString(str)
+			}
+		}
+	}
+
+	return strings.TrimSpace(translatedText.String()), nil
+}
+
+// TranslateBatch sends every text as a repeated "q" param in one request;
+// the free endpoint returns one top-level chunk per input, in order.
+func (p *googleFreeProvider) TranslateBatch(texts []string, targetLang string) ([]string, error) {
+	baseURL := "https://translate.googleapis.com/translate_a/single"
+
+	params := url.Values{}
+	params.Add("client", "gtx")
+	params.Add("sl", p.sourceLang)
+	params.Add("tl", targetLang)
+	params.Add("dt", "t")
+	for _, text := range texts {
+		params.Add("q", text)
+	}
+
+	resp, err := http.Get(baseURL + "?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	var result []interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+
+	chunks, ok := result[0].([]interface{})
+	if !ok || len(chunks) != len(texts) {
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	translated := make([]string, len(texts))
+	for i, item := range chunks {
+		if arr, ok := item.([]interface{}); ok && len(arr) > 0 {
+			if str, ok := arr[0].(string); ok {
+				translated[i] = str
+			}
+		}
+	}
+
+	return translated, nil
+}
+
+// googleV2Provider uses the official Google Cloud Translation API's legacy
+// v2 REST surface (translation/v2), authenticated with a bare API key. This
+// is not the v3 API — v3 requires a GCP project ID and OAuth/service-account
+// auth rather than an API key, and supports glossaries/model selection that
+// this provider does not implement.
+type googleV2Provider struct {
+	apiKey string
+}
+
+func (p *googleV2Provider) Translate(text, targetLang string) (string, error) {
+	params := url.Values{}
+	params.Add("key", p.apiKey)
+	params.Add("q", text)
+	params.Add("target", targetLang)
+	params.Add("format", "text")
+
+	resp, err := http.PostForm("https://translation.googleapis.com/language/translate/v2", params)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result.Data.Translations) == 0 {
+		return "", fmt.Errorf("empty response")
+	}
+
+	return result.Data.Translations[0].TranslatedText, nil
+}
+
+// deeplProvider uses the DeepL API's free-tier endpoint (api-free.deepl.com)
+// for every key; the pro-tier endpoint (api.deepl.com) is not used.
+type deeplProvider struct {
+	apiKey string
+}
+
+func (p *deeplProvider) Translate(text, targetLang string) (string, error) {
+	params := url.Values{}
+	params.Add("auth_key", p.apiKey)
+	params.Add("text", text)
+	params.Add("target_lang", strings.ToUpper(targetLang))
+
+	resp, err := http.PostForm("https://api-free.deepl.com/v2/translate", params)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result.Translations) == 0 {
+		return "", fmt.Errorf("empty response")
+	}
+
+	return result.Translations[0].Text, nil
+}
+
+// TranslateBatch sends every text as a separate element of the request
+// body; DeepL returns one translation per input, in order.
+func (p *deeplProvider) TranslateBatch(texts []string, targetLang string) ([]string, error) {
+	params := url.Values{}
+	params.Add("auth_key", p.apiKey)
+	params.Add("target_lang", strings.ToUpper(targetLang))
+	for _, text := range texts {
+		params.Add("text", text)
+	}
+
+	resp, err := http.PostForm("https://api-free.deepl.com/v2/translate", params)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result.Translations) != len(texts) {
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	translated := make([]string, len(texts))
+	for i, t := range result.Translations {
+		translated[i] = t.Text
+	}
+	return translated, nil
+}
+
+// azureProvider uses Microsoft's Azure Translator REST API.
+type azureProvider struct {
+	apiKey string
+	region string
+}
+
+func (p *azureProvider) Translate(text, targetLang string) (string, error) {
+	params := url.Values{}
+	params.Add("api-version", "3.0")
+	params.Add("to", targetLang)
+
+	payload, err := json.Marshal([]map[string]string{{"Text": text}})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.cognitive.microsofttranslator.com/translate?"+params.Encode(), bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.apiKey)
+	if p.region != "" {
+		req.Header.Set("Ocp-Apim-Subscription-Region", p.region)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result []struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result) == 0 || len(result[0].Translations) == 0 {
+		return "", fmt.Errorf("empty response")
+	}
+
+	return result[0].Translations[0].Text, nil
+}
+
+// TranslateBatch sends every text as its own element of the request body;
+// Azure returns one result object per input, in order.
+func (p *azureProvider) TranslateBatch(texts []string, targetLang string) ([]string, error) {
+	params := url.Values{}
+	params.Add("api-version", "3.0")
+	params.Add("to", targetLang)
+
+	items := make([]map[string]string, len(texts))
+	for i, text := range texts {
+		items[i] = map[string]string{"Text": text}
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.cognitive.microsofttranslator.com/translate?"+params.Encode(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.apiKey)
+	if p.region != "" {
+		req.Header.Set("Ocp-Apim-Subscription-Region", p.region)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result []struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	if len(result) != len(texts) {
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	translated := make([]string, len(texts))
+	for i, r := range result {
+		if len(r.Translations) == 0 {
+			return nil, fmt.Errorf("empty translation for item %d", i)
+		}
+		translated[i] = r.Translations[0].Text
+	}
+	return translated, nil
+}
+
+// libreTranslateProvider uses a LibreTranslate-compatible REST endpoint
+// (the public instance by default, or a self-hosted one via
+// LIBRETRANSLATE_URL).
+type libreTranslateProvider struct {
+	endpoint   string
+	apiKey     string
+	sourceLang string
+}
+
+func (p *libreTranslateProvider) Translate(text, targetLang string) (string, error) {
+	payload := map[string]string{
+		"q":      text,
+		"source": p.sourceLang,
+		"target": targetLang,
+		"format": "text",
+	}
+	if p.apiKey != "" {
+		payload["api_key"] = p.apiKey
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	resp, err := http.Post(p.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+
+	return result.TranslatedText, nil
+}
+
+// noopProvider copies the source text verbatim. Useful for languages that
+// aren't translated yet, or for offline development.
+type noopProvider struct{}
+
+func (p *noopProvider) Translate(text, targetLang string) (string, error) {
+	return text, nil
+}
@@ -0,0 +1,741 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RemoteBackend syncs locale files with an external translation management
+// system (TMS), mirroring AdGuardHome's twosky download/upload flow.
+//
+// Verification status: the crowdinBackend, weblateBackend, and
+// poEditorBackend request/response shapes below are written against each
+// vendor's publicly documented REST API (storage+files+builds for Crowdin,
+// the translations file endpoint for Weblate, terms/translations/export for
+// POEditor) but have not been exercised against a live project — this repo
+// has no tests that pin them with response fixtures, so treat them as
+// documentation-verified, not integration-verified, and smoke-test against a
+// real project before depending on push/pull in production. twoskyBackend is
+// weaker still: Twosky has no canonical public API schema to verify against
+// in the first place (see its doc comment). Accordingly, newRemoteBackend
+// refuses to build any of them unless remote.experimental is set, so push/pull
+// are opt-in rather than wired into the command tree as supported features.
+type RemoteBackend interface {
+	// Push uploads source as the project's source-language strings.
+	Push(source map[string]Message) error
+	// Pull fetches the latest translations for a single locale.
+	Pull(langCode string) (map[string]Message, error)
+}
+
+// newRemoteBackend builds the RemoteBackend named by cfg.Remote.Backend,
+// reading its auth token from a backend-specific environment variable.
+func newRemoteBackend(cfg *Config) (RemoteBackend, error) {
+	if cfg.Remote.ProjectID == "" {
+		return nil, fmt.Errorf("remote.projectId is not set in %s", configFileYAML)
+	}
+	if !cfg.Remote.Experimental {
+		return nil, fmt.Errorf("remote sync is experimental and unverified against a live TMS project — set remote.experimental: true in %s to use it", configFileYAML)
+	}
+
+	switch cfg.Remote.Backend {
+	case "crowdin":
+		token := os.Getenv("CROWDIN_API_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("CROWDIN_API_TOKEN is required for backend %q", cfg.Remote.Backend)
+		}
+		return &crowdinBackend{projectID: cfg.Remote.ProjectID, branch: cfg.Remote.Branch, token: token}, nil
+
+	case "weblate":
+		token := os.Getenv("WEBLATE_API_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("WEBLATE_API_TOKEN is required for backend %q", cfg.Remote.Backend)
+		}
+		if cfg.Remote.Component == "" {
+			return nil, fmt.Errorf("remote.component is required for backend %q", cfg.Remote.Backend)
+		}
+		baseURL := os.Getenv("WEBLATE_URL")
+		if baseURL == "" {
+			baseURL = "https://translate.example.com"
+		}
+		return &weblateBackend{baseURL: baseURL, project: cfg.Remote.ProjectID, component: cfg.Remote.Component, token: token}, nil
+
+	case "poeditor":
+		token := os.Getenv("POEDITOR_API_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("POEDITOR_API_TOKEN is required for backend %q", cfg.Remote.Backend)
+		}
+		return &poEditorBackend{projectID: cfg.Remote.ProjectID, token: token}, nil
+
+	case "twosky", "":
+		return &twoskyBackend{
+			baseURL: envOr("TWOSKY_URL", "https://twosky.example.com"),
+			project: cfg.Remote.ProjectID,
+			token:   os.Getenv("TWOSKY_API_TOKEN"),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown remote backend %q", cfg.Remote.Backend)
+	}
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// maxBuildPolls/buildPollInterval bound how long Crowdin's Pull waits for an
+// asynchronous translations build to finish before giving up.
+const (
+	maxBuildPolls     = 10
+	buildPollInterval = 2 * time.Second
+)
+
+// crowdinBackend syncs via the Crowdin v2 API: Push uploads the source file
+// through the storage API and attaches it as a project file (creating it on
+// first push, updating it on subsequent ones); Pull starts an asynchronous
+// translations build, polls it to completion, and downloads the resulting
+// archive, pulling the target language's file back out of it.
+type crowdinBackend struct {
+	projectID string
+	branch    string
+	token     string
+}
+
+func (b *crowdinBackend) Push(source map[string]Message) error {
+	format, err := newFileFormat(cfg.Format)
+	if err != nil {
+		return err
+	}
+	body, err := format.Encode(source, cfg.Indent, !cfg.FlatKeys)
+	if err != nil {
+		return err
+	}
+
+	storageID, err := b.uploadStorage(body)
+	if err != nil {
+		return fmt.Errorf("failed to upload storage: %v", err)
+	}
+
+	fileID, err := b.findFileID()
+	if err != nil {
+		return fmt.Errorf("failed to look up existing file: %v", err)
+	}
+
+	if fileID != 0 {
+		url := fmt.Sprintf("https://api.crowdin.com/api/v2/projects/%s/files/%d", b.projectID, fileID)
+		return doJSONRequest(http.MethodPut, url, b.token, mustJSON(map[string]int{"storageId": storageID}), nil)
+	}
+
+	url := fmt.Sprintf("https://api.crowdin.com/api/v2/projects/%s/files", b.projectID)
+	return doJSONRequest(http.MethodPost, url, b.token, mustJSON(map[string]interface{}{"storageId": storageID, "name": cfg.SourceFile()}), nil)
+}
+
+// uploadStorage uploads raw file content to Crowdin's storage API, returning
+// the storage ID a file create/update call attaches it by.
+func (b *crowdinBackend) uploadStorage(body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, "https://api.crowdin.com/api/v2/storages", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Crowdin-API-FileName", cfg.SourceFile())
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %v", err)
+	}
+	return result.Data.ID, nil
+}
+
+// findFileID looks up the project file matching cfg.SourceFile()'s name, so
+// Push can update it in place instead of creating a duplicate. Returns 0 if
+// no matching file exists yet.
+func (b *crowdinBackend) findFileID() (int, error) {
+	var result struct {
+		Data []struct {
+			Data struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	url := fmt.Sprintf("https://api.crowdin.com/api/v2/projects/%s/files", b.projectID)
+	if err := doJSONRequest(http.MethodGet, url, b.token, nil, &result); err != nil {
+		return 0, err
+	}
+	for _, f := range result.Data {
+		if f.Data.Name == cfg.SourceFile() {
+			return f.Data.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+func (b *crowdinBackend) Pull(langCode string) (map[string]Message, error) {
+	buildID, err := b.startBuild(langCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start translations build: %v", err)
+	}
+
+	downloadURL, err := b.waitForBuild(buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download translations build: %v", err)
+	}
+
+	archive, err := fetchBytes(downloadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractLocaleFromZip(archive, cfg.fileName(langCode))
+}
+
+// startBuild kicks off an asynchronous Crowdin translations build scoped to
+// langCode, returning the build ID waitForBuild polls.
+func (b *crowdinBackend) startBuild(langCode string) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{"targetLanguageIds": []string{langCode}})
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("https://api.crowdin.com/api/v2/projects/%s/translations/builds", b.projectID)
+	var result struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+	if err := doJSONRequest(http.MethodPost, url, b.token, body, &result); err != nil {
+		return 0, err
+	}
+	return result.Data.ID, nil
+}
+
+// waitForBuild polls a Crowdin translations build until it finishes (or
+// maxBuildPolls is exhausted) and returns the resulting archive's download URL.
+func (b *crowdinBackend) waitForBuild(buildID int) (string, error) {
+	statusURL := fmt.Sprintf("https://api.crowdin.com/api/v2/projects/%s/translations/builds/%d", b.projectID, buildID)
+
+	finished := false
+	for attempt := 0; attempt < maxBuildPolls; attempt++ {
+		var status struct {
+			Data struct {
+				Status string `json:"status"`
+			} `json:"data"`
+		}
+		if err := doJSONRequest(http.MethodGet, statusURL, b.token, nil, &status); err != nil {
+			return "", err
+		}
+		if status.Data.Status == "finished" {
+			finished = true
+			break
+		}
+		time.Sleep(buildPollInterval)
+	}
+	if !finished {
+		return "", fmt.Errorf("build %d did not finish after %d polls", buildID, maxBuildPolls)
+	}
+
+	var download struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	if err := doJSONRequest(http.MethodGet, statusURL+"/download", b.token, nil, &download); err != nil {
+		return "", err
+	}
+	return download.Data.URL, nil
+}
+
+// fetchBytes GETs url and returns the raw response body, for endpoints (like
+// a Crowdin build download URL) that return the file content directly
+// rather than a JSON envelope.
+func fetchBytes(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractLocaleFromZip reads fileName out of a zip archive (as returned by a
+// Crowdin translations build) and decodes it in the configured file format.
+func extractLocaleFromZip(archive []byte, fileName string) (map[string]Message, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build archive: %v", err)
+	}
+
+	for _, f := range reader.File {
+		if filepath.Base(f.Name) != fileName {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		format, err := newFileFormat(cfg.Format)
+		if err != nil {
+			return nil, err
+		}
+		return format.Decode(data)
+	}
+
+	return nil, fmt.Errorf("locale file %q not found in build archive", fileName)
+}
+
+// mustJSON marshals v, panicking on error. Only used for small
+// caller-constructed literal payloads (map[string]int, map[string]interface{})
+// that can never fail to marshal.
+func mustJSON(v interface{}) []byte {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
+
+// weblateBackend syncs via the Weblate REST API. Weblate addresses
+// translations by project/component/language, and its translation file
+// endpoint accepts and returns the component's configured file format
+// directly (not a JSON envelope), so Push/Pull encode/decode through
+// cfg.Format rather than marshaling a map[string]Message as JSON.
+type weblateBackend struct {
+	baseURL   string
+	project   string
+	component string
+	token     string
+}
+
+func (b *weblateBackend) Push(source map[string]Message) error {
+	format, err := newFileFormat(cfg.Format)
+	if err != nil {
+		return err
+	}
+	encoded, err := format.Encode(source, cfg.Indent, !cfg.FlatKeys)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("method", "replace"); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile("file", cfg.SourceFile())
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(encoded); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/translations/%s/%s/%s/file/", b.baseURL, b.project, b.component, cfg.SourceLanguage)
+	req, err := http.NewRequest(http.MethodPost, url, &buf)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Token "+b.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (b *weblateBackend) Pull(langCode string) (map[string]Message, error) {
+	url := fmt.Sprintf("%s/api/translations/%s/%s/%s/file/", b.baseURL, b.project, b.component, langCode)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Token "+b.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := newFileFormat(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	return format.Decode(body)
+}
+
+// poEditorBackend syncs via the POEditor API. Terms (keys) and translations
+// are separate concerns in POEditor's API, so Push adds any new terms first
+// and then uploads translations for them; Pull uses the projects/export
+// flow, which hands back a URL to a flat key/value JSON file rather than
+// the translations inline.
+type poEditorBackend struct {
+	projectID string
+	token     string
+}
+
+// poEditorEnvelope is the {response, result} wrapper every POEditor API call
+// returns, regardless of endpoint.
+type poEditorEnvelope struct {
+	Response struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"response"`
+	Result json.RawMessage `json:"result"`
+}
+
+// poEditorPost submits form to a POEditor API endpoint and validates the
+// {response: {status, message}} envelope every call returns.
+func poEditorPost(endpoint string, form url.Values) (*poEditorEnvelope, error) {
+	resp, err := http.PostForm("https://api.poeditor.com/v2/"+endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var envelope poEditorEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	if envelope.Response.Status != "success" {
+		return nil, fmt.Errorf("API returned %s: %s", envelope.Response.Status, envelope.Response.Message)
+	}
+	return &envelope, nil
+}
+
+func (b *poEditorBackend) Push(source map[string]Message) error {
+	terms := make([]map[string]string, 0, len(source))
+	translations := make([]map[string]interface{}, 0, len(source))
+	for key, msg := range source {
+		terms = append(terms, map[string]string{"term": key})
+		translations = append(translations, map[string]interface{}{
+			"term":        key,
+			"translation": map[string]string{"content": msg.String()},
+		})
+	}
+
+	termsJSON, err := json.Marshal(terms)
+	if err != nil {
+		return err
+	}
+	if _, err := poEditorPost("terms/add", url.Values{
+		"api_token": {b.token},
+		"id":        {b.projectID},
+		"data":      {string(termsJSON)},
+	}); err != nil {
+		return fmt.Errorf("failed to add terms: %v", err)
+	}
+
+	translationsJSON, err := json.Marshal(translations)
+	if err != nil {
+		return err
+	}
+	if _, err := poEditorPost("translations/add", url.Values{
+		"api_token": {b.token},
+		"id":        {b.projectID},
+		"language":  {cfg.SourceLanguage},
+		"data":      {string(translationsJSON)},
+	}); err != nil {
+		return fmt.Errorf("failed to add translations: %v", err)
+	}
+	return nil
+}
+
+func (b *poEditorBackend) Pull(langCode string) (map[string]Message, error) {
+	envelope, err := poEditorPost("projects/export", url.Values{
+		"api_token": {b.token},
+		"id":        {b.projectID},
+		"language":  {langCode},
+		"type":      {"key_value_json"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request export: %v", err)
+	}
+
+	var export struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(envelope.Result, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse export result: %v", err)
+	}
+
+	body, err := fetchBytes(export.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download export: %v", err)
+	}
+
+	var flat map[string]string
+	if err := json.Unmarshal(body, &flat); err != nil {
+		return nil, fmt.Errorf("failed to parse export file: %v", err)
+	}
+
+	result := make(map[string]Message, len(flat))
+	for key, text := range flat {
+		result[key] = Message{Text: text}
+	}
+	return result, nil
+}
+
+// twoskyBackend is a generic Twosky-style multipart upload/download,
+// matching the pattern AdGuardHome's translations tool uses against its own
+// Twosky-compatible TMS. Unlike Crowdin/Weblate/POEditor, Twosky has no
+// canonical public API schema to verify this against — AdGuardHome talks to
+// a privately-run instance — so this request/response shape is a best-effort
+// approximation rather than something checked against real documentation.
+type twoskyBackend struct {
+	baseURL string
+	project string
+	token   string
+}
+
+func (b *twoskyBackend) Push(source map[string]Message) error {
+	body, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("project", b.project); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile("file", "en.json")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, b.baseURL+"/upload", &buf)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (b *twoskyBackend) Pull(langCode string) (map[string]Message, error) {
+	url := fmt.Sprintf("%s/download?project=%s&language=%s", b.baseURL, b.project, langCode)
+	var result map[string]Message
+	if err := doJSONRequest(http.MethodGet, url, b.token, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// doJSONRequest issues a JSON request with an optional bearer token,
+// decoding the response body into out when out is non-nil.
+func doJSONRequest(method, url, token string, body []byte, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %v", err)
+	}
+	return nil
+}
+
+func newPushCmd() *cobra.Command {
+	var only string
+
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Upload source strings to the remote TMS and pull back translations",
+		Long:  "Push uploads the source locale file to the configured remote backend (Crowdin/Weblate/POEditor/Twosky), then pulls back translated locales. Experimental: requires remote.experimental: true, since no backend has been exercised against a live TMS project",
+		Run: func(cmd *cobra.Command, args []string) {
+			runSync(true, only)
+		},
+	}
+	cmd.Flags().StringVar(&only, "only", "", "limit to a single target language code")
+	return cmd
+}
+
+func newPullCmd() *cobra.Command {
+	var only string
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download the latest translations from the remote TMS",
+		Long:  "Pull fetches translations from the configured remote backend and merges them into local files without clobbering keys not present remotely. Experimental: requires remote.experimental: true, since no backend has been exercised against a live TMS project",
+		Run: func(cmd *cobra.Command, args []string) {
+			runSync(false, only)
+		},
+	}
+	cmd.Flags().StringVar(&only, "only", "", "limit to a single target language code")
+	return cmd
+}
+
+// syncSummary tallies what a pull changed for one language.
+type syncSummary struct {
+	lang                               string
+	added, updated, unchanged, missing int
+}
+
+// runSync drives push and/or pull against the configured remote backend and
+// prints a per-language summary table.
+func runSync(push bool, only string) {
+	if err := initEnv(); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	backend, err := newRemoteBackend(cfg)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+
+	if push {
+		if err := backend.Push(sourceData); err != nil {
+			fmt.Printf("❌ Error: failed to push source: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Pushed %d keys from %s\n\n", len(sourceData), cfg.SourceFile())
+	}
+
+	var summaries []syncSummary
+	for _, lang := range cfg.TargetLanguages {
+		if lang.Code == cfg.SourceLanguage || (only != "" && lang.Code != only) {
+			continue
+		}
+
+		remote, err := backend.Pull(lang.Code)
+		if err != nil {
+			fmt.Printf("❌ %s: failed to pull: %v\n", lang.Code, err)
+			continue
+		}
+
+		path := filepath.Join(basePath, cfg.LocaleFile(lang))
+		local := loadLocaleFile(path)
+		summary := syncSummary{lang: lang.Code}
+
+		for key := range sourceData {
+			remoteMsg, ok := remote[key]
+			if !ok {
+				summary.missing++
+				continue
+			}
+			if localMsg, existed := local[key]; !existed {
+				summary.added++
+			} else if !localMsg.Equal(remoteMsg) {
+				summary.updated++
+			} else {
+				summary.unchanged++
+			}
+			local[key] = remoteMsg
+		}
+
+		if err := saveLocaleFile(path, local); err != nil {
+			fmt.Printf("❌ %s: failed to save: %v\n", lang.Code, err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+
+	fmt.Println("\nlanguage  added  updated  unchanged  missing")
+	for _, s := range summaries {
+		fmt.Printf("%-8s  %5d  %7d  %9d  %7d\n", s.lang, s.added, s.updated, s.unchanged, s.missing)
+	}
+}
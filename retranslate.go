@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newRetranslateCmd() *cobra.Command {
+	var concurrency int
+	var rps float64
+	var keys []string
+	var onlyLangs []string
+
+	cmd := &cobra.Command{
+		Use:   "retranslate",
+		Short: "Force-refresh translations for selected keys or languages",
+		Long:  "Re-translates keys from the source locale, bypassing the cache, for every target language or a --key/--lang subset",
+		Run: func(cmd *cobra.Command, args []string) {
+			runRetranslate(keys, onlyLangs, concurrency, rps)
+		},
+	}
+
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultConcurrency, "number of languages to translate concurrently")
+	cmd.Flags().Float64Var(&rps, "rps", defaultRPS, "max translation requests per second")
+	cmd.Flags().StringSliceVar(&keys, "key", nil, "limit to specific keys (repeatable, default: all keys)")
+	cmd.Flags().StringSliceVar(&onlyLangs, "lang", nil, "limit to specific target language codes (repeatable, default: all)")
+
+	return cmd
+}
+
+// runRetranslate force-refreshes the given keys (or every key) for the
+// given languages (or every target language), bypassing the cache.
+func runRetranslate(keys, langs []string, concurrency int, rps float64) {
+	if err := initEnvWithOptions(rps, true); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	translator, ok := provider.(*Translator)
+	if !ok {
+		fmt.Println("❌ Error: retranslate requires a cache-aware translator")
+		return
+	}
+
+	sourcePath := filepath.Join(basePath, cfg.SourceFile())
+	sourceData := loadLocaleFile(sourcePath)
+	if len(keys) == 0 {
+		for k := range sourceData {
+			keys = append(keys, k)
+		}
+	}
+
+	targets := cfg.TargetLanguages
+	if len(langs) > 0 {
+		wanted := map[string]bool{}
+		for _, l := range langs {
+			wanted[l] = true
+		}
+		targets = nil
+		for _, lang := range cfg.TargetLanguages {
+			if wanted[lang.Code] {
+				targets = append(targets, lang)
+			}
+		}
+	}
+
+	fmt.Println()
+	forEachLanguage(targets, concurrency, func(lang LanguageConfig) {
+		if lang.Code == cfg.SourceLanguage {
+			return
+		}
+
+		file := cfg.LocaleFile(lang)
+		path := filepath.Join(basePath, file)
+		data := loadLocaleFile(path)
+
+		// Plain, placeholder-free keys can go through the provider's batch
+		// API in one request; everything else (ICU plurals, placeholders,
+		// per-form objects) needs translateMessage's segment-aware handling.
+		var plainKeys, structuredKeys []string
+		for _, key := range keys {
+			source, exists := sourceData[key]
+			if !exists {
+				continue
+			}
+			if source.Forms == nil && !icuPluralRe.MatchString(source.Text) && !placeholderRe.MatchString(source.Text) {
+				plainKeys = append(plainKeys, key)
+			} else {
+				structuredKeys = append(structuredKeys, key)
+			}
+		}
+
+		if len(plainKeys) > 0 {
+			texts := make([]string, len(plainKeys))
+			for i, key := range plainKeys {
+				texts[i] = sourceData[key].Text
+			}
+
+			translated, err := translator.forceTranslateBatch(texts, lang.Code)
+			if err != nil {
+				fmt.Printf("❌ %s: batch retranslate failed (%v)\n", file, err)
+			} else {
+				for i, key := range plainKeys {
+					data[key] = Message{Text: translated[i]}
+					fmt.Printf("🔄 %s: %s: %s\n", file, key, translated[i])
+				}
+			}
+		}
+
+		for _, key := range structuredKeys {
+			source := sourceData[key]
+			translated, err := translateMessage(source, forceProvider{translator}, lang.Code)
+			if err != nil {
+				fmt.Printf("❌ %s: retranslate '%s' failed (%v)\n", file, key, err)
+				continue
+			}
+			if err := validateMessage(key, source, translated); err != nil {
+				fmt.Printf("❌ %s: %v\n", file, err)
+				continue
+			}
+
+			data[key] = translated
+			fmt.Printf("🔄 %s: %s: %s\n", file, key, translated)
+		}
+
+		if err := saveLocaleFile(path, data); err != nil {
+			fmt.Printf("❌ %s: failed to save: %v\n", file, err)
+		}
+	})
+	saveTranslatorCache()
+
+	fmt.Println("\n✨ retranslate complete!")
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cacheFilePath   = ".i18nator-cache.json"
+	providerVersion = "v1"
+	maxRetries      = 3
+
+	defaultConcurrency = 4
+	defaultRPS         = 5.0
+)
+
+// Translator wraps a TranslationProvider with a local cache, a token-bucket
+// rate limiter, and retry-with-backoff, and implements TranslationProvider
+// itself so it's a drop-in replacement for the raw provider at every call
+// site that translates a single string.
+type Translator struct {
+	provider TranslationProvider
+	limiter  *rateLimiter
+	cache    *translationCache
+}
+
+// newTranslator builds a Translator around provider. rps <= 0 disables rate
+// limiting; useCache controls whether .i18nator-cache.json is consulted and
+// updated.
+func newTranslator(provider TranslationProvider, rps float64, useCache bool) *Translator {
+	var cache *translationCache
+	if useCache {
+		cache = loadTranslationCache(cacheFilePath)
+	} else {
+		cache = newTranslationCache(cacheFilePath, false)
+	}
+	return &Translator{provider: provider, limiter: newRateLimiter(rps), cache: cache}
+}
+
+// Translate looks up the cache first, then calls the wrapped provider with
+// rate limiting and retry-with-backoff on transient (429/5xx) errors.
+func (t *Translator) Translate(text, targetLang string) (string, error) {
+	key := cacheKey(text, targetLang)
+	if cached, ok := t.cache.get(key); ok {
+		return cached, nil
+	}
+
+	result, err := t.translateWithRetry(text, targetLang)
+	if err != nil {
+		return "", err
+	}
+
+	t.cache.set(key, result)
+	return result, nil
+}
+
+// forceTranslate bypasses the cache (used by `retranslate`) but still
+// writes the refreshed result back into it.
+func (t *Translator) forceTranslate(text, targetLang string) (string, error) {
+	key := cacheKey(text, targetLang)
+	t.cache.delete(key)
+
+	result, err := t.translateWithRetry(text, targetLang)
+	if err != nil {
+		return "", err
+	}
+
+	t.cache.set(key, result)
+	return result, nil
+}
+
+func (t *Translator) translateWithRetry(text, targetLang string) (string, error) {
+	var result string
+	var err error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		t.limiter.wait()
+
+		result, err = t.provider.Translate(text, targetLang)
+		if err == nil {
+			return result, nil
+		}
+		if !isRetryable(err) || attempt == maxRetries {
+			return "", err
+		}
+		time.Sleep(backoff(attempt))
+	}
+
+	return "", err
+}
+
+// save persists any new cache entries to disk.
+func (t *Translator) save() error {
+	return t.cache.save()
+}
+
+// TranslateBatch translates multiple independent strings into targetLang,
+// serving cache hits locally and sending only the misses to the provider —
+// as one batched request when it implements BatchTranslationProvider.
+func (t *Translator) TranslateBatch(texts []string, targetLang string) ([]string, error) {
+	return t.translateBatch(texts, targetLang, false)
+}
+
+// forceTranslateBatch is TranslateBatch without consulting the cache first.
+func (t *Translator) forceTranslateBatch(texts []string, targetLang string) ([]string, error) {
+	return t.translateBatch(texts, targetLang, true)
+}
+
+func (t *Translator) translateBatch(texts []string, targetLang string, force bool) ([]string, error) {
+	results := make([]string, len(texts))
+	var missIdx []int
+	var missTexts []string
+
+	for i, text := range texts {
+		key := cacheKey(text, targetLang)
+		if force {
+			t.cache.delete(key)
+		} else if cached, ok := t.cache.get(key); ok {
+			results[i] = cached
+			continue
+		}
+		missIdx = append(missIdx, i)
+		missTexts = append(missTexts, text)
+	}
+
+	if len(missTexts) == 0 {
+		return results, nil
+	}
+
+	translated, err := t.translateBatchWithRetry(missTexts, targetLang)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, idx := range missIdx {
+		results[idx] = translated[i]
+		t.cache.set(cacheKey(texts[idx], targetLang), translated[i])
+	}
+
+	return results, nil
+}
+
+func (t *Translator) translateBatchWithRetry(texts []string, targetLang string) ([]string, error) {
+	batcher, ok := t.provider.(BatchTranslationProvider)
+	if !ok {
+		results := make([]string, len(texts))
+		for i, text := range texts {
+			r, err := t.translateWithRetry(text, targetLang)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = r
+		}
+		return results, nil
+	}
+
+	var results []string
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		t.limiter.wait()
+
+		results, err = batcher.TranslateBatch(texts, targetLang)
+		if err == nil {
+			return results, nil
+		}
+		if !isRetryable(err) || attempt == maxRetries {
+			return nil, err
+		}
+		time.Sleep(backoff(attempt))
+	}
+
+	return nil, err
+}
+
+// forceProvider adapts Translator.forceTranslate to the TranslationProvider
+// interface, so `retranslate` can reuse translateMessage's ICU/placeholder
+// handling while bypassing the cache.
+type forceProvider struct {
+	translator *Translator
+}
+
+func (f forceProvider) Translate(text, targetLang string) (string, error) {
+	return f.translator.forceTranslate(text, targetLang)
+}
+
+// isRetryable reports whether err looks like a transient HTTP failure
+// (429 or 5xx) worth retrying.
+func isRetryable(err error) bool {
+	msg := err.Error()
+	for _, code := range []string{"429", "500", "502", "503", "504"} {
+		if strings.Contains(msg, "status "+code) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns an exponential delay with jitter for the given
+// zero-indexed attempt.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base + jitter
+}
+
+// cacheKey derives a cache entry's key from the translation request it
+// represents. The configured provider is not part of the key, so entries are
+// shared across providers; only a change to providerVersion invalidates them.
+func cacheKey(text, targetLang string) string {
+	sum := sha256.Sum256([]byte(text + "|" + targetLang + "|" + providerVersion))
+	return hex.EncodeToString(sum[:])
+}
+
+// rateLimiter is a simple token-bucket limiter: it releases at most one
+// token every 1/rps, blocking callers that exceed the budget.
+type rateLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	last     time.Time
+}
+
+func newRateLimiter(rps float64) *rateLimiter {
+	if rps <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+func (r *rateLimiter) wait() {
+	if r.interval == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	next := r.last.Add(r.interval)
+	if now.Before(next) {
+		time.Sleep(next.Sub(now))
+		now = next
+	}
+	r.last = now
+}
+
+// translationCache persists translated strings to a local JSON file, keyed
+// by sha256(sourceText|targetLang|providerVersion), so re-adding an
+// existing source value never re-hits the network.
+type translationCache struct {
+	path    string
+	enabled bool
+	mu      sync.Mutex
+	entries map[string]string
+	dirty   bool
+}
+
+func newTranslationCache(path string, enabled bool) *translationCache {
+	return &translationCache{path: path, enabled: enabled, entries: map[string]string{}}
+}
+
+func loadTranslationCache(path string) *translationCache {
+	c := newTranslationCache(path, true)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	_ = json.Unmarshal(data, &c.entries)
+	if c.entries == nil {
+		c.entries = map[string]string{}
+	}
+	return c
+}
+
+func (c *translationCache) get(key string) (string, bool) {
+	if !c.enabled {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+func (c *translationCache) set(key, value string) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+	c.dirty = true
+}
+
+func (c *translationCache) delete(key string) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *translationCache) save() error {
+	if !c.enabled || !c.dirty {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// forEachLanguage runs fn over languages using a bounded worker pool of the
+// given size, blocking until every language has been processed.
+func forEachLanguage(languages []LanguageConfig, concurrency int, fn func(LanguageConfig)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, lang := range languages {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(l LanguageConfig) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(l)
+		}(lang)
+	}
+
+	wg.Wait()
+}